@@ -26,6 +26,7 @@ import (
 	"github.com/bytebase/bytebase/backend/generated-go/v1/v1connect"
 	metricapi "github.com/bytebase/bytebase/backend/metric"
 	"github.com/bytebase/bytebase/backend/plugin/metric"
+	approvalmetrics "github.com/bytebase/bytebase/backend/runner/approval/metrics"
 	"github.com/bytebase/bytebase/backend/runner/metricreport"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
@@ -745,6 +746,7 @@ func (s *IssueService) ApproveIssue(ctx context.Context, req *connect.Request[v1
 		return nil, connect.NewError(connect.CodePermissionDenied, errors.Errorf("cannot approve because the user does not have the required permission"))
 	}
 
+	approverCount := len(payload.Approval.Approvers)
 	payload.Approval.Approvers = append(payload.Approval.Approvers, &storepb.IssuePayloadApproval_Approver{
 		Status:      storepb.IssuePayloadApproval_Approver_APPROVED,
 		PrincipalId: int32(user.ID),
@@ -759,10 +761,30 @@ func (s *IssueService) ApproveIssue(ctx context.Context, req *connect.Request[v1
 		PayloadUpsert: &storepb.Issue{
 			Approval: payload.Approval,
 		},
+		ApproverCount: &approverCount,
 	})
+	if errors.Is(err, store.ErrIssueApprovalChanged) {
+		// A concurrent request approved the issue first. It is the same approval if it came from the same user.
+		current, err := s.getIssueMessage(ctx, req.Msg.Name)
+		if err != nil {
+			return nil, err
+		}
+		if isLatestApprover(current.Payload.Approval, user.ID) {
+			issueV1, err := s.convertToIssue(ctx, current)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, errors.Errorf("failed to convert to issue, error: %v", err))
+			}
+			return connect.NewResponse(issueV1), nil
+		}
+		return nil, connect.NewError(connect.CodeAborted, errors.Errorf("the issue approval has changed, please try again"))
+	}
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, errors.Errorf("failed to update issue, error: %v", err))
 	}
+	approvalmetrics.ObserveIssueApprovalDecision(storepb.IssuePayloadApproval_Approver_APPROVED)
+	if approved {
+		approvalmetrics.ObserveIssueApproved(issue.Payload.GetRiskLevel(), issue.CreatedAt)
+	}
 
 	// Grant the privilege if the issue is approved.
 	if approved && issue.Type == storepb.Issue_GRANT_REQUEST {
@@ -889,6 +911,16 @@ func (s *IssueService) ApproveIssue(ctx context.Context, req *connect.Request[v1
 	return connect.NewResponse(issueV1), nil
 }
 
+// isLatestApprover returns true if the latest approval of the issue is an approve by the user.
+func isLatestApprover(approval *storepb.IssuePayloadApproval, userID int) bool {
+	approvers := approval.GetApprovers()
+	if len(approvers) == 0 {
+		return false
+	}
+	latest := approvers[len(approvers)-1]
+	return latest.Status == storepb.IssuePayloadApproval_Approver_APPROVED && latest.PrincipalId == int32(userID)
+}
+
 // RejectIssue rejects a issue.
 func (s *IssueService) RejectIssue(ctx context.Context, req *connect.Request[v1pb.RejectIssueRequest]) (*connect.Response[v1pb.Issue], error) {
 	issue, err := s.getIssueMessage(ctx, req.Msg.Name)
@@ -941,6 +973,7 @@ func (s *IssueService) RejectIssue(ctx context.Context, req *connect.Request[v1p
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, errors.Errorf("failed to update issue, error: %v", err))
 	}
+	approvalmetrics.ObserveIssueApprovalDecision(storepb.IssuePayloadApproval_Approver_REJECTED)
 
 	if err := func() error {
 		p := &storepb.IssueCommentPayload{
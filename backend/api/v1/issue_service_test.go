@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+)
+
+func TestIsLatestApprover(t *testing.T) {
+	a := require.New(t)
+	approver := func(principalID int32, status storepb.IssuePayloadApproval_Approver_Status) *storepb.IssuePayloadApproval_Approver {
+		return &storepb.IssuePayloadApproval_Approver{PrincipalId: principalID, Status: status}
+	}
+
+	testCases := []struct {
+		description string
+		approval    *storepb.IssuePayloadApproval
+		want        bool
+	}{
+		{
+			description: "no approval",
+			approval:    nil,
+			want:        false,
+		},
+		{
+			description: "no approvers yet",
+			approval:    &storepb.IssuePayloadApproval{},
+			want:        false,
+		},
+		{
+			description: "retry after the user approved the latest step",
+			approval: &storepb.IssuePayloadApproval{Approvers: []*storepb.IssuePayloadApproval_Approver{
+				approver(101, storepb.IssuePayloadApproval_Approver_APPROVED),
+			}},
+			want: true,
+		},
+		{
+			description: "another user approved the latest step",
+			approval: &storepb.IssuePayloadApproval{Approvers: []*storepb.IssuePayloadApproval_Approver{
+				approver(101, storepb.IssuePayloadApproval_Approver_APPROVED),
+				approver(102, storepb.IssuePayloadApproval_Approver_APPROVED),
+			}},
+			want: false,
+		},
+		{
+			description: "the user rejected the latest step",
+			approval: &storepb.IssuePayloadApproval{Approvers: []*storepb.IssuePayloadApproval_Approver{
+				approver(101, storepb.IssuePayloadApproval_Approver_REJECTED),
+			}},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		a.Equal(tc.want, isLatestApprover(tc.approval, 101), tc.description)
+	}
+}
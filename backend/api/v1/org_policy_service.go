@@ -3,14 +3,17 @@ package v1
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/enterprise"
 	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/backend/generated-go/v1"
@@ -158,6 +161,8 @@ func (s *OrgPolicyService) UpdatePolicy(ctx context.Context, req *connect.Reques
 	if req.Msg.UpdateMask == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("update_mask must be set"))
 	}
+	// audit log.
+	s.setPolicyAuditServiceData(ctx, policy)
 
 	patch := &store.UpdatePolicyMessage{
 		ResourceType: policy.ResourceType,
@@ -235,6 +240,9 @@ func (s *OrgPolicyService) DeletePolicy(ctx context.Context, req *connect.Reques
 		return nil, err
 	}
 
+	// audit log.
+	s.setPolicyAuditServiceData(ctx, policy)
+
 	if err := s.store.DeletePolicyV2(ctx, policy); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -242,6 +250,26 @@ func (s *OrgPolicyService) DeletePolicy(ctx context.Context, req *connect.Reques
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
+// setPolicyAuditServiceData records the policy before it is changed in the audit log,
+// so weakening a policy such as the masking rules can be traced. The request holds the new value.
+func (s *OrgPolicyService) setPolicyAuditServiceData(ctx context.Context, policy *store.PolicyMessage) {
+	setServiceData, ok := common.GetSetServiceDataFromContext(ctx)
+	if !ok {
+		return
+	}
+	v1pbPolicy, err := s.convertToPolicy(ctx, policy)
+	if err != nil {
+		slog.Warn("audit: failed to convert to v1.Policy", log.BBError(err))
+		return
+	}
+	p, err := anypb.New(v1pbPolicy)
+	if err != nil {
+		slog.Warn("audit: failed to convert to anypb.Any", log.BBError(err))
+		return
+	}
+	setServiceData(p)
+}
+
 // extractPolicyTypeFromName extracts the policy type from a policy name.
 func extractPolicyTypeFromName(policyName string) (storepb.Policy_Type, error) {
 	tokens := strings.Split(policyName, common.PolicyNamePrefix)
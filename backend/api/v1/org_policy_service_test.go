@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/backend/generated-go/v1"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestSetPolicyAuditServiceData(t *testing.T) {
+	a := require.New(t)
+
+	var serviceData *anypb.Any
+	ctx := common.WithSetServiceData(context.Background(), func(p *anypb.Any) {
+		serviceData = p
+	})
+	s := &OrgPolicyService{}
+	s.setPolicyAuditServiceData(ctx, &store.PolicyMessage{
+		ResourceType: storepb.Policy_WORKSPACE,
+		Type:         storepb.Policy_MASKING_RULE,
+		Payload:      `{"rules": [{"id": "rule-1", "condition": {"expression": "resource.classification_level == \"S3\""}, "semanticType": "full-mask"}]}`,
+		Enforce:      true,
+	})
+
+	// The audit log keeps the policy as it was before the change.
+	a.NotNil(serviceData)
+	policy := &v1pb.Policy{}
+	a.NoError(serviceData.UnmarshalTo(policy))
+	a.Equal("policies/masking_rule", policy.Name)
+	rules := policy.GetMaskingRulePolicy().GetRules()
+	a.Len(rules, 1)
+	a.Equal("full-mask", rules[0].SemanticType)
+	a.Equal(`resource.classification_level == "S3"`, rules[0].Condition.Expression)
+
+	// Nothing is recorded for calls that are not audited.
+	a.NotPanics(func() {
+		s.setPolicyAuditServiceData(context.Background(), &store.PolicyMessage{Type: storepb.Policy_MASKING_RULE, Payload: "{}"})
+	})
+}
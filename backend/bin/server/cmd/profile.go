@@ -11,19 +11,26 @@ import (
 
 func getBaseProfile(dataDir string) *config.Profile {
 	config := &config.Profile{
-		ExternalURL:       flags.externalURL,
-		Port:              flags.port,     // Using flags.port as our gRPC server port.
-		DatastorePort:     flags.port + 2, // Using flags.port + 2 as our datastore port.
-		HA:                flags.ha,
-		SaaS:              flags.saas,
-		EnableJSONLogging: flags.enableJSONLogging,
-		IsDocker:          isDocker(),
-		DataDir:           dataDir,
-		Demo:              flags.demo,
-		Version:           version,
-		GitCommit:         gitcommit,
-		PgURL:             os.Getenv("PG_URL"),
-		DeployID:          uuid.NewString()[:8],
+		ExternalURL:        flags.externalURL,
+		Port:               flags.port,     // Using flags.port as our gRPC server port.
+		DatastorePort:      flags.port + 2, // Using flags.port + 2 as our datastore port.
+		HA:                 flags.ha,
+		SaaS:               flags.saas,
+		EnableJSONLogging:  flags.enableJSONLogging,
+		IsDocker:           isDocker(),
+		DataDir:            dataDir,
+		Demo:               flags.demo,
+		WarmCaches:         flags.warmCaches,
+		StoreMethodMetrics: flags.storeMethodMetrics,
+		StoreQueryTimeout:  flags.storeQueryTimeout,
+		StoreDrainTimeout:  flags.storeDrainTimeout,
+		StoreCacheSizes:    flags.storeCacheSizes,
+		SlowQueryThreshold: flags.slowQueryThreshold,
+		Version:            version,
+		GitCommit:          gitcommit,
+		PgURL:              os.Getenv("PG_URL"),
+		PgReplicaURL:       os.Getenv("PG_REPLICA_URL"),
+		DeployID:           uuid.NewString()[:8],
 	}
 
 	config.LastActiveTS.Store(time.Now().Unix())
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/jackc/pgconn"
 	"github.com/pkg/errors"
@@ -71,6 +72,18 @@ var (
 		debug bool
 		// memoryProfileThreshold is the threshold of memory usage in bytes to trigger a memory profile.
 		memoryProfileThreshold uint64
+		// warmCaches pre-loads frequently read metadata into the store caches on startup.
+		warmCaches bool
+		// storeMethodMetrics records metadata database query metrics per store method.
+		storeMethodMetrics bool
+		// storeQueryTimeout bounds metadata database queries whose context has no deadline.
+		storeQueryTimeout time.Duration
+		// storeDrainTimeout is how long shutdown waits for in-flight metadata database operations.
+		storeDrainTimeout time.Duration
+		// storeCacheSizes overrides the capacity of store caches by cache name.
+		storeCacheSizes map[string]int
+		// slowQueryThreshold is the duration above which metadata database queries are logged as slow.
+		slowQueryThreshold time.Duration
 	}
 
 	rootCmd = &cobra.Command{
@@ -105,6 +118,12 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&flags.demo, "demo", false, "run in demo mode.")
 	rootCmd.PersistentFlags().BoolVar(&flags.debug, "debug", false, "whether to enable debug level logging")
 	rootCmd.PersistentFlags().Uint64Var(&flags.memoryProfileThreshold, "memory-profile-threshold", 0, "the threshold of memory usage in bytes to trigger a memory profile")
+	rootCmd.PersistentFlags().BoolVar(&flags.warmCaches, "warm-caches", false, "pre-load frequently read metadata into caches on startup")
+	rootCmd.PersistentFlags().BoolVar(&flags.storeMethodMetrics, "store-method-metrics", true, "record metadata database query metrics per store method, which walks the call stack of every query")
+	rootCmd.PersistentFlags().DurationVar(&flags.storeQueryTimeout, "store-query-timeout", 2*time.Minute, "the timeout of metadata database queries and transactions without a deadline of their own, 0 to disable. Heavy operations such as purges get 15 times as long")
+	rootCmd.PersistentFlags().DurationVar(&flags.storeDrainTimeout, "store-drain-timeout", 10*time.Second, "how long shutdown waits for in-flight metadata database queries and transactions before closing the connections")
+	rootCmd.PersistentFlags().StringToIntVar(&flags.storeCacheSizes, "store-cache-sizes", nil, "override the capacity of metadata caches, e.g. database=65536,database-metadata=256. Caches are user, instance, database, project, policy, issue, pipeline, setting, identity-provider, database-group, role, group, sheet, sheet-statement and database-metadata")
+	rootCmd.PersistentFlags().DurationVar(&flags.slowQueryThreshold, "slow-query-threshold", 500*time.Millisecond, "log metadata database queries slower than this threshold, 0 to disable")
 }
 
 // -----------------------------------Command Line Config END--------------------------------------
@@ -3,6 +3,7 @@ package config
 
 import (
 	"sync/atomic"
+	"time"
 
 	"github.com/bytebase/bytebase/backend/common"
 	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
@@ -30,6 +31,18 @@ type Profile struct {
 	Demo bool
 	// HA replica mode.
 	HA bool
+	// WarmCaches pre-loads frequently read metadata into the store caches on startup.
+	WarmCaches bool
+	// StoreMethodMetrics records metadata database query metrics per store method.
+	StoreMethodMetrics bool
+	// StoreQueryTimeout bounds metadata database queries whose context has no deadline. Zero disables it.
+	StoreQueryTimeout time.Duration
+	// StoreDrainTimeout is how long shutdown waits for in-flight metadata database operations. Zero does not wait.
+	StoreDrainTimeout time.Duration
+	// StoreCacheSizes overrides the capacity of store caches by cache name, e.g. "database" or "database-metadata".
+	StoreCacheSizes map[string]int
+	// SlowQueryThreshold is the duration above which metadata database queries are logged as slow. Zero disables it.
+	SlowQueryThreshold time.Duration
 
 	// Version is the bytebase's server version
 	Version string
@@ -37,6 +50,8 @@ type Profile struct {
 	GitCommit string
 	// PgURL is the optional external PostgreSQL instance connection url
 	PgURL string
+	// PgReplicaURL is the optional read replica connection url of the external PostgreSQL instance.
+	PgReplicaURL string
 	// MetricConnectionKey is the connection key for metric.
 	MetricConnectionKey string
 
@@ -0,0 +1,61 @@
+// Package metrics defines the Prometheus metrics of issue approvals.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+)
+
+var (
+	// issueApprovalDecisions counts approval decisions by status, e.g. "APPROVED" or "REJECTED".
+	issueApprovalDecisions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bytebase_issue_approval_decisions_total",
+			Help: "Number of issue approval decisions by status",
+		},
+		[]string{"status"},
+	)
+
+	// issueApprovalDuration tracks the time from issue creation until its last approval step is approved.
+	issueApprovalDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "bytebase_issue_approval_duration_seconds",
+			Help: "Time from issue creation to approval by risk level",
+			// 1 minute to about 45 days.
+			Buckets: prometheus.ExponentialBuckets(60, 4, 9),
+		},
+		[]string{"risk_level"},
+	)
+
+	// issueApprovalPending is the number of open issues waiting for approval.
+	issueApprovalPending = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bytebase_issue_approval_pending",
+			Help: "Number of open issues waiting for approval by risk level",
+		},
+		[]string{"risk_level"},
+	)
+)
+
+// ObserveIssueApprovalDecision records an approval decision on an issue.
+func ObserveIssueApprovalDecision(status storepb.IssuePayloadApproval_Approver_Status) {
+	issueApprovalDecisions.WithLabelValues(status.String()).Inc()
+}
+
+// ObserveIssueApproved records that an issue created at createdAt has been approved.
+func ObserveIssueApproved(riskLevel storepb.RiskLevel, createdAt time.Time) {
+	issueApprovalDuration.WithLabelValues(riskLevel.String()).Observe(time.Since(createdAt).Seconds())
+}
+
+// SetPendingApprovalIssues sets the number of open issues waiting for approval by risk level.
+// Risk levels missing from counts are reset to zero.
+func SetPendingApprovalIssues(counts map[storepb.RiskLevel]int) {
+	for _, v := range storepb.RiskLevel_value {
+		riskLevel := storepb.RiskLevel(v)
+		issueApprovalPending.WithLabelValues(riskLevel.String()).Set(float64(counts[riskLevel]))
+	}
+}
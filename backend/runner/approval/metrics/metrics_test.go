@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+)
+
+func TestApprovalMetrics(t *testing.T) {
+	a := require.New(t)
+
+	// A two-step approval: the first step is approved, the second rejected, then a new issue is approved in one step.
+	approved := testutil.ToFloat64(issueApprovalDecisions.WithLabelValues("APPROVED"))
+	rejected := testutil.ToFloat64(issueApprovalDecisions.WithLabelValues("REJECTED"))
+	ObserveIssueApprovalDecision(storepb.IssuePayloadApproval_Approver_APPROVED)
+	ObserveIssueApprovalDecision(storepb.IssuePayloadApproval_Approver_REJECTED)
+	ObserveIssueApprovalDecision(storepb.IssuePayloadApproval_Approver_APPROVED)
+	ObserveIssueApproved(storepb.RiskLevel_HIGH, time.Now().Add(-time.Hour))
+	a.Equal(approved+2, testutil.ToFloat64(issueApprovalDecisions.WithLabelValues("APPROVED")))
+	a.Equal(rejected+1, testutil.ToFloat64(issueApprovalDecisions.WithLabelValues("REJECTED")))
+	a.Equal(1, testutil.CollectAndCount(issueApprovalDuration))
+
+	SetPendingApprovalIssues(map[storepb.RiskLevel]int{storepb.RiskLevel_HIGH: 3, storepb.RiskLevel_LOW: 1})
+	a.Equal(float64(3), testutil.ToFloat64(issueApprovalPending.WithLabelValues("HIGH")))
+	a.Equal(float64(1), testutil.ToFloat64(issueApprovalPending.WithLabelValues("LOW")))
+
+	// Levels that are no longer pending drop to zero.
+	SetPendingApprovalIssues(map[storepb.RiskLevel]int{storepb.RiskLevel_LOW: 2})
+	a.Equal(float64(0), testutil.ToFloat64(issueApprovalPending.WithLabelValues("HIGH")))
+	a.Equal(float64(2), testutil.ToFloat64(issueApprovalPending.WithLabelValues("LOW")))
+}
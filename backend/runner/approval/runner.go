@@ -26,6 +26,7 @@ import (
 	"github.com/bytebase/bytebase/backend/enterprise"
 	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/backend/generated-go/v1"
+	approvalmetrics "github.com/bytebase/bytebase/backend/runner/approval/metrics"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 )
@@ -52,18 +53,27 @@ func NewRunner(store *store.Store, sheetManager *sheet.Manager, dbFactory *dbfac
 	}
 }
 
-const approvalRunnerInterval = 1 * time.Second
+const (
+	approvalRunnerInterval = 1 * time.Second
+	// approvalMetricsInterval is the interval between refreshes of the pending approval metrics.
+	approvalMetricsInterval = 1 * time.Minute
+)
 
 // Run runs the runner.
 func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	ticker := time.NewTicker(approvalRunnerInterval)
 	defer ticker.Stop()
+	metricsTicker := time.NewTicker(approvalMetricsInterval)
+	defer metricsTicker.Stop()
 	defer wg.Done()
 	slog.Debug(fmt.Sprintf("Approval runner started and will run every %v", approvalRunnerInterval))
 	r.retryFindApprovalTemplate(ctx)
+	r.refreshApprovalMetrics(ctx)
 
 	for {
 		select {
+		case <-metricsTicker.C:
+			r.refreshApprovalMetrics(ctx)
 		case <-ticker.C:
 			func() {
 				defer func() {
@@ -83,6 +93,16 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
+// refreshApprovalMetrics updates the pending approval gauges with one aggregate query.
+func (r *Runner) refreshApprovalMetrics(ctx context.Context) {
+	counts, err := r.store.CountPendingApprovalIssues(ctx)
+	if err != nil {
+		slog.Warn("failed to count pending approval issues", log.BBError(err))
+		return
+	}
+	approvalmetrics.SetPendingApprovalIssues(counts)
+}
+
 func (r *Runner) runOnce(ctx context.Context) {
 	approvalSetting, err := r.store.GetWorkspaceApprovalSetting(ctx)
 	if err != nil {
@@ -17,12 +17,14 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/store"
 )
 
 func configureEchoRouters(
 	e *echo.Echo,
 	lspServer *lsp.Server,
 	directorySyncServer *directorysync.Service,
+	stores *store.Store,
 	profile *config.Profile,
 ) {
 	e.Use(recoverMiddleware)
@@ -70,6 +72,19 @@ func configureEchoRouters(
 	e.GET("/healthz", func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK")
 	})
+	// Readiness of the metadata database, for probes that must notice a wedged connection pool.
+	// The endpoint is unauthenticated, so failures are only detailed in the server log.
+	e.GET("/healthz/store", func(c echo.Context) error {
+		status := stores.HealthCheck(c.Request().Context())
+		code := http.StatusOK
+		if !status.Healthy {
+			code = http.StatusServiceUnavailable
+		}
+		return c.JSON(code, map[string]any{
+			"healthy":             status.Healthy,
+			"consecutiveFailures": status.ConsecutiveFailures,
+		})
+	})
 
 	// LSP server.
 	e.GET(lspAPI, lspServer.Router)
@@ -107,7 +107,7 @@ func NewServer(ctx context.Context, profile *config.Profile) (*Server, error) {
 		}
 	}()
 
-	var pgURL string
+	var pgURL, pgReplicaURL string
 	if profile.UseEmbedDB() {
 		pgDataDir := path.Join(profile.DataDir, "pgdata")
 		if profile.Demo {
@@ -122,10 +122,23 @@ func NewServer(ctx context.Context, profile *config.Profile) (*Server, error) {
 		pgURL = fmt.Sprintf("host=%s port=%d user=bb database=bb", common.GetPostgresSocketDir(), profile.DatastorePort)
 	} else {
 		pgURL = profile.PgURL
+		pgReplicaURL = profile.PgReplicaURL
 	}
 
+	cacheSizes, err := store.ParseCacheSizes(profile.StoreCacheSizes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid store cache sizes")
+	}
 	// Connect to the instance that stores bytebase's own metadata.
-	stores, err := store.New(ctx, pgURL, !profile.HA)
+	stores, err := store.New(ctx, pgURL,
+		store.WithReadReplica(pgReplicaURL),
+		store.WithCacheEnabled(!profile.HA),
+		store.WithCacheSizes(cacheSizes),
+		store.WithMethodMetrics(profile.StoreMethodMetrics),
+		store.WithQueryTimeout(profile.StoreQueryTimeout),
+		store.WithDrainTimeout(profile.StoreDrainTimeout),
+		store.WithSlowQueryThreshold(profile.SlowQueryThreshold),
+	)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to new store")
 	}
@@ -141,6 +154,8 @@ func NewServer(ctx context.Context, profile *config.Profile) (*Server, error) {
 		return nil, errors.Wrapf(err, "failed to migrate schema")
 	}
 	s.store = stores
+	// Runners must exit before the store closes the database connections.
+	stores.RegisterStopper(s.runnerWG.Wait)
 	sheetManager := sheet.NewManager(stores)
 
 	// Initialize sample instance manager and start sample instances if they exist
@@ -168,6 +183,9 @@ func NewServer(ctx context.Context, profile *config.Profile) (*Server, error) {
 	if err := s.initializeSetting(ctx); err != nil {
 		return nil, errors.Wrap(err, "failed to init config")
 	}
+	if profile.WarmCaches {
+		s.store.WarmCaches(ctx)
+	}
 	secret, err := s.store.GetSecret(ctx)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get secret")
@@ -219,7 +237,7 @@ func NewServer(ctx context.Context, profile *config.Profile) (*Server, error) {
 	if err := configureGrpcRouters(ctx, s.echoServer, s.store, sheetManager, s.dbFactory, s.licenseService, s.profile, s.metricReporter, s.stateCfg, s.schemaSyncer, s.webhookManager, s.iamManager, secret, s.sampleInstanceManager); err != nil {
 		return nil, errors.Wrapf(err, "failed to configure gRPC routers")
 	}
-	configureEchoRouters(s.echoServer, s.lspServer, directorySyncServer, profile)
+	configureEchoRouters(s.echoServer, s.lspServer, directorySyncServer, s.store, profile)
 
 	serverStarted = true
 	return s, nil
@@ -307,10 +325,7 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		}
 	}
 
-	// Wait for all runners to exit.
-	s.runnerWG.Wait()
-
-	// Close db connection
+	// Close db connection. The store waits for the runners to exit first, see RegisterStopper in NewServer.
 	if s.store != nil {
 		if err := s.store.Close(); err != nil {
 			return err
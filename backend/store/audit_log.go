@@ -52,7 +52,7 @@ func (s *Store) CreateAuditLog(ctx context.Context, payload *storepb.AuditLog) e
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, sql, args...); err != nil {
+	if _, err := s.execContext(ctx, sql, args...); err != nil {
 		return errors.Wrapf(err, "failed to create audit log")
 	}
 	return nil
@@ -96,7 +96,7 @@ func (s *Store) SearchAuditLogs(ctx context.Context, find *AuditLogFind) ([]*Aud
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, sql, args...)
+	rows, err := s.readQueryContext(ctx, sql, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to query context")
 	}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+// cacheWarmBudget bounds how long WarmCaches may delay startup.
+const cacheWarmBudget = 10 * time.Second
+
+// WarmCaches pre-loads small, frequently read sets into the store caches so the first requests
+// after a start do not all miss and hit the database at once.
+// It is best-effort: failures are logged and never returned, and it gives up once cacheWarmBudget is spent.
+func (s *Store) WarmCaches(ctx context.Context) {
+	if !s.enableCache {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, cacheWarmBudget)
+	defer cancel()
+
+	start := time.Now()
+	// Settings are read on almost every request, and roles on every permission check.
+	if _, err := s.ListSettingV2(ctx, &FindSettingMessage{}); err != nil {
+		slog.Warn("failed to warm setting cache", log.BBError(err))
+	}
+	if _, err := s.ListRoles(ctx); err != nil {
+		slog.Warn("failed to warm role cache", log.BBError(err))
+	}
+	slog.Info("warmed store caches",
+		slog.Int("settings", s.settingCache.Len()),
+		slog.Int("roles", s.rolesCache.Len()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+)
+
+func TestWarmCaches(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	fake.result = func(query string) *fakeRows {
+		if strings.Contains(query, "FROM setting") {
+			return &fakeRows{
+				columns: []string{"name", "value"},
+				values: [][]driver.Value{
+					{storepb.SettingName_WORKSPACE_ID.String(), "workspace"},
+					{storepb.SettingName_BRANDING_LOGO.String(), "logo"},
+				},
+			}
+		}
+		return &fakeRows{
+			columns: []string{"resource_id", "name", "description", "permissions"},
+			values:  [][]driver.Value{{"projectOwner", "Project Owner", "", []byte(`{"permissions": ["bb.projects.get"]}`)}},
+		}
+	}
+	s := newTestStore(db)
+	s.enableCache = true
+	a.NoError(s.initCaches(defaultCacheSizes))
+
+	s.WarmCaches(ctx)
+	queries := len(fake.recorded())
+
+	workspaceID, err := s.GetWorkspaceID(ctx)
+	a.NoError(err)
+	a.Equal("workspace", workspaceID)
+	role, err := s.GetRole(ctx, "projectOwner")
+	a.NoError(err)
+	a.True(role.Permissions["bb.projects.get"])
+	a.Len(fake.recorded(), queries, "warmed objects must be served from the caches")
+}
+
+func TestWarmCachesBestEffort(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	fake.hook = func(_ context.Context, query string) error {
+		if strings.Contains(query, "FROM setting") {
+			return errors.New("setting table is unavailable")
+		}
+		return nil
+	}
+	fake.result = func(string) *fakeRows {
+		return &fakeRows{columns: []string{"resource_id", "name", "description", "permissions"}}
+	}
+	s := newTestStore(db)
+	s.enableCache = true
+	a.NoError(s.initCaches(defaultCacheSizes))
+
+	// A failing set does not stop the others from being warmed.
+	s.WarmCaches(ctx)
+	var roleQueries int
+	for _, q := range fake.recorded() {
+		if strings.Contains(q, "FROM role") {
+			roleQueries++
+		}
+	}
+	a.Equal(1, roleQueries)
+}
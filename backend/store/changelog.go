@@ -72,7 +72,7 @@ type UpdateChangelogMessage struct {
 }
 
 func (s *Store) CreateChangelog(ctx context.Context, create *ChangelogMessage) (int64, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to begin tx")
 	}
@@ -140,7 +140,7 @@ func (s *Store) UpdateChangelog(ctx context.Context, update *UpdateChangelogMess
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to begin tx")
 	}
@@ -242,7 +242,7 @@ func (s *Store) ListChangelogs(ctx context.Context, find *FindChangelogMessage)
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to query")
 	}
@@ -40,16 +40,13 @@ func (s *Store) DeleteDatabaseGroup(ctx context.Context, projectID, resourceID s
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		return errors.Wrapf(err, "failed to begin transaction")
-	}
-	defer tx.Rollback()
-	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
-		return errors.Wrapf(err, "failed to execute")
-	}
-	if err := tx.Commit(); err != nil {
-		return errors.Wrapf(err, "failed to commit transaction")
+	if err := s.RunInTx(ctx, func(tx *trackedTx) error {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return errors.Wrapf(err, "failed to execute")
+		}
+		return nil
+	}); err != nil {
+		return err
 	}
 	s.databaseGroupCache.Remove(getDatabaseGroupCacheKey(projectID, resourceID))
 	return nil
@@ -57,7 +54,7 @@ func (s *Store) DeleteDatabaseGroup(ctx context.Context, projectID, resourceID s
 
 // ListDatabaseGroups lists database groups.
 func (s *Store) ListDatabaseGroups(ctx context.Context, find *FindDatabaseGroupMessage) ([]*DatabaseGroupMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +82,7 @@ func (s *Store) GetDatabaseGroup(ctx context.Context, find *FindDatabaseGroupMes
 			return v, nil
 		}
 	}
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +107,7 @@ func (s *Store) GetDatabaseGroup(ctx context.Context, find *FindDatabaseGroupMes
 	return databaseGroups[0], nil
 }
 
-func (*Store) listDatabaseGroupImpl(ctx context.Context, txn *sql.Tx, find *FindDatabaseGroupMessage) ([]*DatabaseGroupMessage, error) {
+func (*Store) listDatabaseGroupImpl(ctx context.Context, txn *trackedTx, find *FindDatabaseGroupMessage) ([]*DatabaseGroupMessage, error) {
 	q := qb.Q().Space(`
 		SELECT
 			project,
@@ -190,27 +187,24 @@ func (s *Store) UpdateDatabaseGroup(ctx context.Context, projectID, resourceID s
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to commit ")
-	}
-
 	var updatedDatabaseGroup DatabaseGroupMessage
 	var exprBytes []byte
-	if err := tx.QueryRowContext(
-		ctx,
-		query,
-		args...,
-	).Scan(
-		&updatedDatabaseGroup.ProjectID,
-		&updatedDatabaseGroup.ResourceID,
-		&updatedDatabaseGroup.Title,
-		&exprBytes,
-	); err != nil {
-		return nil, errors.Wrapf(err, "failed to scan")
-	}
-	if err := tx.Commit(); err != nil {
-		return nil, errors.Wrapf(err, "failed to commit transaction")
+	if err := s.RunInTx(ctx, func(tx *trackedTx) error {
+		if err := tx.QueryRowContext(
+			ctx,
+			query,
+			args...,
+		).Scan(
+			&updatedDatabaseGroup.ProjectID,
+			&updatedDatabaseGroup.ResourceID,
+			&updatedDatabaseGroup.Title,
+			&exprBytes,
+		); err != nil {
+			return errors.Wrapf(err, "failed to scan")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 	var expression expr.Expr
 	if err := common.ProtojsonUnmarshaler.Unmarshal(exprBytes, &expression); err != nil {
@@ -242,17 +236,13 @@ func (s *Store) CreateDatabaseGroup(ctx context.Context, create *DatabaseGroupMe
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to begin transaction")
-	}
-	defer tx.Rollback()
-
-	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
-		return nil, errors.Wrapf(err, "failed to scan")
-	}
-	if err := tx.Commit(); err != nil {
-		return nil, errors.Wrapf(err, "failed to commit transaction")
+	if err := s.RunInTx(ctx, func(tx *trackedTx) error {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return errors.Wrapf(err, "failed to execute")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	s.databaseGroupCache.Add(getDatabaseGroupCacheKey(create.ProjectID, create.ResourceID), create)
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultQueryTimeout bounds store queries whose context has no deadline,
+	// so a hung metadata database cannot block callers forever.
+	defaultQueryTimeout = 2 * time.Minute
+	// longQueryTimeoutFactor scales the query timeout into the budget for known-heavy operations
+	// such as batch upserts and purges.
+	longQueryTimeoutFactor = 15
+)
+
+// The accessors below are the only way store methods should reach the metadata database.
+// Keeping them in one place lets connection routing and instrumentation evolve without touching every store file.
+//
+// The accessors apply the store's query timeout unless the context already has a deadline.
+// A timed-out query returns an error satisfying errors.Is(err, context.DeadlineExceeded).
+// The timeout is released as soon as rows are closed or read to the end, a row is scanned,
+// or a transaction is committed or rolled back, so callers must always do so.
+// Until then, the operation also counts as in flight for Close.
+
+// queryContext executes a query that returns rows.
+func (s *Store) queryContext(ctx context.Context, query string, args ...any) (*trackedRows, error) {
+	if err := s.startOperation(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := s.withQueryTimeout(ctx)
+	release := s.releaseOperation(cancel)
+	rows, err := s.dbConnManager.GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &trackedRows{Rows: rows, release: release}, nil
+}
+
+// readQueryContext executes a query that returns rows on the read replica if one is available.
+// Only use it for list queries whose results tolerate replication lag.
+func (s *Store) readQueryContext(ctx context.Context, query string, args ...any) (*trackedRows, error) {
+	if err := s.startOperation(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := s.withQueryTimeout(ctx)
+	release := s.releaseOperation(cancel)
+	rows, err := s.dbConnManager.GetReadDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &trackedRows{Rows: rows, release: release}, nil
+}
+
+// rowScanner is the result of queryRowContext.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// errRow is a row whose query could not run.
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
+
+// queryRowContext executes a query that is expected to return at most one row.
+func (s *Store) queryRowContext(ctx context.Context, query string, args ...any) rowScanner {
+	if err := s.startOperation(); err != nil {
+		return errRow{err: err}
+	}
+	ctx, cancel := s.withQueryTimeout(ctx)
+	row := s.dbConnManager.GetDB().QueryRowContext(ctx, query, args...)
+	return &trackedRow{row: row, release: s.releaseOperation(cancel)}
+}
+
+// execContext executes a query without returning any rows.
+func (s *Store) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if err := s.startOperation(); err != nil {
+		return nil, err
+	}
+	defer s.inflight.Done()
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+	return s.dbConnManager.GetDB().ExecContext(ctx, query, args...)
+}
+
+// beginTx starts a transaction.
+// The query timeout covers the whole transaction rather than each statement in it:
+// every statement of the transaction is bounded by the deadline set when it began.
+func (s *Store) beginTx(ctx context.Context, opts *sql.TxOptions) (*trackedTx, error) {
+	if err := s.startOperation(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := s.withQueryTimeout(ctx)
+	release := s.releaseOperation(cancel)
+	tx, err := s.dbConnManager.GetDB().BeginTx(ctx, opts)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	deadline, _ := ctx.Deadline()
+	return &trackedTx{Tx: tx, deadline: deadline, release: release}, nil
+}
+
+// RunInTx runs fn in a read-write transaction.
+// The transaction is committed if fn returns nil, and rolled back if fn returns an error or panics.
+func (s *Store) RunInTx(ctx context.Context, fn func(tx *trackedTx) error) error {
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// withQueryTimeout bounds ctx by the store's query timeout if it has no deadline yet.
+func (s *Store) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || s.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.queryTimeout)
+}
+
+// withLongQueryTimeout gives known-heavy operations a larger budget than the query timeout.
+// The accessors keep this deadline because the context already has one.
+// Like the query timeout, it applies no deadline if timeouts are disabled.
+func (s *Store) withLongQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.queryTimeout*longQueryTimeoutFactor)
+}
+
+// trackedRows are the rows returned by the accessors.
+// Their query context and operation are released once they are closed or read to the end.
+type trackedRows struct {
+	*sql.Rows
+	release func()
+}
+
+// Next prepares the next row. The rows are closed once there are no more.
+func (r *trackedRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.release()
+	return false
+}
+
+// Close closes the rows.
+func (r *trackedRows) Close() error {
+	defer r.release()
+	return r.Rows.Close()
+}
+
+// trackedRow is the row returned by queryRowContext. Its query context and operation are released once it is scanned.
+type trackedRow struct {
+	row     *sql.Row
+	release func()
+}
+
+func (r *trackedRow) Scan(dest ...any) error {
+	defer r.release()
+	return r.row.Scan(dest...)
+}
+
+// trackedTx is a transaction started by the accessors.
+// Its context and operation are released once it is committed or rolled back.
+type trackedTx struct {
+	*sql.Tx
+	// deadline is the deadline of the transaction context, or zero if it has none.
+	// database/sql does not interrupt a running statement when the transaction context expires,
+	// so each statement is bounded by it as well.
+	deadline time.Time
+	release  func()
+}
+
+// ExecContext executes a query without returning any rows.
+func (tx *trackedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, cancel := tx.withDeadline(ctx)
+	defer cancel()
+	return tx.Tx.ExecContext(ctx, query, args...)
+}
+
+// QueryContext executes a query that returns rows.
+func (tx *trackedTx) QueryContext(ctx context.Context, query string, args ...any) (*trackedRows, error) {
+	ctx, cancel := tx.withDeadline(ctx)
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &trackedRows{Rows: rows, release: cancel}, nil
+}
+
+// QueryRowContext executes a query that is expected to return at most one row.
+func (tx *trackedTx) QueryRowContext(ctx context.Context, query string, args ...any) rowScanner {
+	ctx, cancel := tx.withDeadline(ctx)
+	return &trackedRow{row: tx.Tx.QueryRowContext(ctx, query, args...), release: cancel}
+}
+
+// withDeadline bounds the context of a statement by the transaction deadline.
+func (tx *trackedTx) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if tx.deadline.IsZero() {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, tx.deadline)
+}
+
+// Commit commits the transaction.
+func (tx *trackedTx) Commit() error {
+	defer tx.release()
+	return tx.Tx.Commit()
+}
+
+// Rollback aborts the transaction.
+func (tx *trackedTx) Rollback() error {
+	defer tx.release()
+	return tx.Tx.Rollback()
+}
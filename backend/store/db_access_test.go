@@ -0,0 +1,350 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDB is an in-memory database/sql driver that records the statements it receives.
+type fakeDB struct {
+	mu      sync.Mutex
+	queries []string
+	// hook, if set, runs before every statement and may block or fail it.
+	hook func(ctx context.Context, query string) error
+	// result, if set, returns the rows of a query. Queries return a single row `1` otherwise.
+	result func(query string) *fakeRows
+}
+
+func newFakeDB(t *testing.T) (*fakeDB, *sql.DB) {
+	t.Helper()
+	f := &fakeDB{}
+	db := sql.OpenDB(&fakeConnector{db: f})
+	t.Cleanup(func() { db.Close() })
+	return f, db
+}
+
+func (f *fakeDB) record(ctx context.Context, query string) error {
+	f.mu.Lock()
+	f.queries = append(f.queries, query)
+	hook := f.hook
+	f.mu.Unlock()
+	if hook != nil {
+		return hook(ctx, query)
+	}
+	return nil
+}
+
+func (f *fakeDB) recorded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.queries...)
+}
+
+type fakeConnector struct {
+	db *fakeDB
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: c.db}, nil
+}
+
+// Close is called when the sql.DB is closed.
+func (c *fakeConnector) Close() error {
+	return c.db.record(context.Background(), "CLOSE")
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("use fakeConnector")
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (*fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	if err := c.db.record(ctx, "BEGIN"); err != nil {
+		return nil, err
+	}
+	return &fakeTx{conn: c}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.db.record(ctx, query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.db.record(ctx, query); err != nil {
+		return nil, err
+	}
+	if c.db.result != nil {
+		return c.db.result(query), nil
+	}
+	return &fakeRows{columns: []string{"value"}, values: [][]driver.Value{{int64(1)}}}, nil
+}
+
+func (c *fakeConn) Ping(ctx context.Context) error {
+	return c.db.record(ctx, "PING")
+}
+
+type fakeTx struct {
+	conn *fakeConn
+}
+
+func (tx *fakeTx) Commit() error {
+	return tx.conn.db.record(context.Background(), "COMMIT")
+}
+
+func (tx *fakeTx) Rollback() error {
+	return tx.conn.db.record(context.Background(), "ROLLBACK")
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (*fakeStmt) Close() error {
+	return nil
+}
+
+func (*fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, toNamedValues(args))
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, toNamedValues(args))
+}
+
+func toNamedValues(args []driver.Value) []driver.NamedValue {
+	var values []driver.NamedValue
+	for i, v := range args {
+		values = append(values, driver.NamedValue{Ordinal: i + 1, Value: v})
+	}
+	return values
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
+
+func newTestStore(db *sql.DB) *Store {
+	return &Store{dbConnManager: &DBConnectionManager{db: db}}
+}
+
+func TestStoreAccessors(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	s := newTestStore(db)
+
+	var v int
+	a.NoError(s.queryRowContext(ctx, "SELECT 1").Scan(&v))
+	a.Equal(1, v)
+
+	rows, err := s.queryContext(ctx, "SELECT 2")
+	a.NoError(err)
+	a.True(rows.Next())
+	a.NoError(rows.Close())
+
+	_, err = s.execContext(ctx, "UPDATE t SET a = 1")
+	a.NoError(err)
+
+	tx, err := s.beginTx(ctx, nil)
+	a.NoError(err)
+	_, err = tx.ExecContext(ctx, "DELETE FROM t")
+	a.NoError(err)
+	a.NoError(tx.Commit())
+
+	a.Equal([]string{"SELECT 1", "SELECT 2", "UPDATE t SET a = 1", "BEGIN", "DELETE FROM t", "COMMIT"}, fake.recorded())
+}
+
+func TestReadReplicaRouting(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	primary, primaryDB := newFakeDB(t)
+	replica, replicaDB := newFakeDB(t)
+	m := &DBConnectionManager{db: primaryDB}
+	m.replicaDB.Store(replicaDB)
+	m.replicaHealthy.Store(true)
+	s := &Store{dbConnManager: m}
+
+	query := func(q string) {
+		rows, err := s.readQueryContext(ctx, q)
+		a.NoError(err)
+		a.NoError(rows.Close())
+	}
+
+	query("SELECT replica")
+	_, err := s.execContext(ctx, "UPDATE primary")
+	a.NoError(err)
+	rows, err := s.queryContext(ctx, "SELECT primary")
+	a.NoError(err)
+	a.NoError(rows.Close())
+
+	// Reads fall back to the primary once the replica fails its health check.
+	replica.hook = func(_ context.Context, q string) error {
+		if q == "PING" {
+			return errors.New("replica is down")
+		}
+		return nil
+	}
+	a.Error(m.pingReplica(ctx))
+	a.False(m.replicaHealthy.Load())
+	query("SELECT fallback")
+
+	// And go back to the replica when it recovers.
+	replica.hook = nil
+	a.NoError(m.pingReplica(ctx))
+	a.True(m.replicaHealthy.Load())
+	query("SELECT recovered")
+
+	a.Equal([]string{"UPDATE primary", "SELECT primary", "SELECT fallback"}, primary.recorded())
+	a.Equal([]string{"SELECT replica", "PING", "PING", "SELECT recovered"}, replica.recorded())
+}
+
+func TestReadReplicaReconnect(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	_, primaryDB := newFakeDB(t)
+	replica, replicaDB := newFakeDB(t)
+	m := &DBConnectionManager{db: primaryDB}
+
+	// The replica is unreachable at first, so reads use the primary.
+	m.openReplica = func(context.Context) (*sql.DB, error) {
+		return nil, errors.New("replica is down")
+	}
+	a.Error(m.pingReplica(ctx))
+	a.Nil(m.replicaDB.Load())
+	a.Same(primaryDB, m.GetReadDB())
+
+	// The health check connects once the replica is reachable.
+	m.openReplica = func(context.Context) (*sql.DB, error) {
+		return replicaDB, nil
+	}
+	a.NoError(m.pingReplica(ctx))
+	a.Same(replicaDB, m.GetReadDB())
+	a.NoError(m.pingReplica(ctx))
+	a.Equal([]string{"PING"}, replica.recorded())
+}
+
+func TestReadReplicaNotConfigured(t *testing.T) {
+	a := require.New(t)
+	primary, primaryDB := newFakeDB(t)
+	s := newTestStore(primaryDB)
+
+	rows, err := s.readQueryContext(context.Background(), "SELECT 1")
+	a.NoError(err)
+	a.NoError(rows.Close())
+	a.Equal([]string{"SELECT 1"}, primary.recorded())
+}
+
+func TestQueryContextReleased(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	s := newTestStore(db)
+	s.queryTimeout = time.Minute
+	var queryCtx context.Context
+	fake.hook = func(ctx context.Context, q string) error {
+		if q != "COMMIT" {
+			queryCtx = ctx
+		}
+		return nil
+	}
+
+	rows, err := s.queryContext(ctx, "SELECT 1")
+	a.NoError(err)
+	a.NoError(queryCtx.Err())
+	a.NoError(rows.Close())
+	a.ErrorIs(queryCtx.Err(), context.Canceled)
+
+	// Reading the rows to the end releases them as well.
+	rows, err = s.queryContext(ctx, "SELECT 2")
+	a.NoError(err)
+	for rows.Next() {
+		a.NoError(queryCtx.Err())
+	}
+	a.ErrorIs(queryCtx.Err(), context.Canceled)
+
+	var v int
+	row := s.queryRowContext(ctx, "SELECT 3")
+	a.NoError(queryCtx.Err())
+	a.NoError(row.Scan(&v))
+	a.ErrorIs(queryCtx.Err(), context.Canceled)
+
+	tx, err := s.beginTx(ctx, nil)
+	a.NoError(err)
+	a.NoError(queryCtx.Err())
+	a.NoError(tx.Commit())
+	a.ErrorIs(queryCtx.Err(), context.Canceled)
+}
+
+func TestLongQueryTimeout(t *testing.T) {
+	a := require.New(t)
+	s := &Store{queryTimeout: time.Minute}
+
+	ctx, cancel := s.withLongQueryTimeout(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	a.True(ok)
+	a.WithinDuration(time.Now().Add(15*time.Minute), deadline, time.Minute)
+
+	// No deadline is applied if query timeouts are disabled.
+	s.queryTimeout = 0
+	ctx, cancel = s.withLongQueryTimeout(context.Background())
+	defer cancel()
+	_, ok = ctx.Deadline()
+	a.False(ok)
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/common/testcontainer"
+	"github.com/bytebase/bytebase/backend/migrator"
+)
+
+// newTestcontainerStore creates a Store on a PostgreSQL container with the metadata schema.
+func newTestcontainerStore(t *testing.T, opts ...Option) *Store {
+	t.Helper()
+	ctx := context.Background()
+	pgContainer := testcontainer.GetTestPgContainer(ctx, t)
+	t.Cleanup(func() { pgContainer.Close(ctx) })
+
+	pgURL := fmt.Sprintf("host=%s port=%s user=postgres password=root-password database=postgres", pgContainer.GetHost(), pgContainer.GetPort())
+	s, err := New(ctx, pgURL, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	require.NoError(t, migrator.MigrateSchema(ctx, s.GetDB()))
+	return s
+}
+
+func TestQueryTimeoutWithTestcontainer(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	s := newTestcontainerStore(t, WithQueryTimeout(200*time.Millisecond))
+
+	// assertTimedOut checks that a statement sleeping for 5s was interrupted by the query timeout.
+	assertTimedOut := func(begin time.Time, err error) {
+		t.Helper()
+		a.ErrorIs(err, context.DeadlineExceeded)
+		a.Less(time.Since(begin), 2*time.Second)
+	}
+
+	begin := time.Now()
+	_, err := s.execContext(ctx, "SELECT pg_sleep(5)")
+	assertTimedOut(begin, err)
+
+	begin = time.Now()
+	rows, err := s.queryContext(ctx, "SELECT pg_sleep(5)")
+	if err == nil {
+		// The query may also fail while reading its result.
+		a.False(rows.Next())
+		err = rows.Err()
+		a.NoError(rows.Close())
+	}
+	assertTimedOut(begin, err)
+
+	begin = time.Now()
+	var v string
+	err = s.queryRowContext(ctx, "SELECT pg_sleep(5)::TEXT").Scan(&v)
+	assertTimedOut(begin, err)
+
+	// Statements in a transaction are bounded by the transaction deadline, even though their own context has none.
+	tx, err := s.beginTx(ctx, nil)
+	a.NoError(err)
+	begin = time.Now()
+	_, err = tx.ExecContext(ctx, "SELECT pg_sleep(5)")
+	assertTimedOut(begin, err)
+	_ = tx.Rollback()
+
+	begin = time.Now()
+	err = s.RunInTx(ctx, func(tx *trackedTx) error {
+		return tx.QueryRowContext(ctx, "SELECT pg_sleep(5)::TEXT").Scan(&v)
+	})
+	assertTimedOut(begin, err)
+
+	// Starting a transaction times out as well if no connection becomes available.
+	s.GetDB().SetMaxOpenConns(1)
+	conn, err := s.GetDB().Conn(ctx)
+	a.NoError(err)
+	begin = time.Now()
+	_, err = s.beginTx(ctx, nil)
+	assertTimedOut(begin, err)
+	a.NoError(conn.Close())
+
+	// A deadline set by the caller takes precedence over the query timeout.
+	longCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err = s.execContext(longCtx, "SELECT pg_sleep(0.5)")
+	a.NoError(err)
+}
+
+func TestRunInTxWithTestcontainer(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	s := newTestcontainerStore(t)
+	_, err := s.execContext(ctx, "CREATE TABLE run_in_tx (id INTEGER PRIMARY KEY)")
+	a.NoError(err)
+
+	exists := func(id int) bool {
+		var ok bool
+		a.NoError(s.queryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM run_in_tx WHERE id = $1)", id).Scan(&ok))
+		return ok
+	}
+	insert := func(tx *trackedTx, id int) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO run_in_tx (id) VALUES ($1)", id)
+		return err
+	}
+
+	// The transaction is committed if fn returns nil.
+	a.NoError(s.RunInTx(ctx, func(tx *trackedTx) error {
+		return insert(tx, 1)
+	}))
+	a.True(exists(1))
+
+	// It is rolled back if fn returns an error.
+	errBoom := errors.New("boom")
+	err = s.RunInTx(ctx, func(tx *trackedTx) error {
+		if err := insert(tx, 2); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	a.ErrorIs(err, errBoom)
+	a.False(exists(2))
+
+	// Or if fn panics.
+	a.Panics(func() {
+		_ = s.RunInTx(ctx, func(tx *trackedTx) error {
+			if err := insert(tx, 3); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	})
+	a.False(exists(3))
+
+	// A failed statement fails the transaction.
+	err = s.RunInTx(ctx, func(tx *trackedTx) error {
+		if err := insert(tx, 4); err != nil {
+			return err
+		}
+		return insert(tx, 1)
+	})
+	a.Error(err)
+	a.False(exists(4))
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -17,6 +18,9 @@ import (
 	"github.com/bytebase/bytebase/backend/common/qb"
 )
 
+// replicaHealthCheckInterval is the interval between read replica health checks.
+const replicaHealthCheckInterval = 10 * time.Second
+
 // DBConnectionManager manages database connections with support for dynamic updates.
 type DBConnectionManager struct {
 	mu          sync.Mutex
@@ -24,14 +28,33 @@ type DBConnectionManager struct {
 	pgURLOrFile string // Either a PostgreSQL URL or a file path
 	watcher     *fsnotify.Watcher
 	stopWatcher chan struct{}
+	tracer      *metadataDBTracer
+
+	// Optional read replica. Reads fall back to the primary while the replica is unhealthy.
+	// The replica connection is nil until the health check first reaches it.
+	replicaURL     string
+	replicaDB      atomic.Pointer[sql.DB]
+	replicaHealthy atomic.Bool
+	openReplica    func(ctx context.Context) (*sql.DB, error)
+	stopReplica    chan struct{}
+	replicaStopped chan struct{}
 }
 
 // NewDBConnectionManager creates a new database connection manager.
-func NewDBConnectionManager(pgURLOrFile string) *DBConnectionManager {
-	return &DBConnectionManager{
+// replicaURL is the optional PostgreSQL URL of a read replica.
+// methodMetrics sets whether query metrics are recorded per Store method,
+// and queries slower than slowQueryThreshold are logged unless it is zero.
+func NewDBConnectionManager(pgURLOrFile, replicaURL string, methodMetrics bool, slowQueryThreshold time.Duration) *DBConnectionManager {
+	m := &DBConnectionManager{
 		pgURLOrFile: pgURLOrFile,
 		stopWatcher: make(chan struct{}),
+		tracer:      &metadataDBTracer{methodMetrics: methodMetrics, slowQueryThreshold: slowQueryThreshold},
+		replicaURL:  replicaURL,
+	}
+	m.openReplica = func(ctx context.Context) (*sql.DB, error) {
+		return createConnectionWithTracer(ctx, m.replicaURL, m.tracer)
 	}
+	return m
 }
 
 // Initialize sets up the database connection.
@@ -60,12 +83,23 @@ func (m *DBConnectionManager) Initialize(ctx context.Context) error {
 	}
 
 	// Create initial connection
-	db, err := createConnectionWithTracer(ctx, pgURL)
+	db, err := createConnectionWithTracer(ctx, pgURL, m.tracer)
 	if err != nil {
 		return err
 	}
 
 	m.db = db
+
+	if m.replicaURL != "" {
+		// The replica is optional, so failing to reach it must not block the server from starting.
+		// The health check keeps trying to connect to it.
+		if err := m.pingReplica(ctx); err != nil {
+			slog.Warn("Failed to connect to the read replica, reads will use the primary", "error", err)
+		}
+		m.stopReplica = make(chan struct{})
+		m.replicaStopped = make(chan struct{})
+		go m.checkReplicaHealth(ctx)
+	}
 	return nil
 }
 
@@ -74,13 +108,77 @@ func (m *DBConnectionManager) GetDB() *sql.DB {
 	return m.db
 }
 
-// Close stops the file watcher and closes the database connection.
+// GetReadDB returns the read replica connection if it is configured and healthy, otherwise the primary connection.
+// Only use it for reads that tolerate replication lag.
+func (m *DBConnectionManager) GetReadDB() *sql.DB {
+	if replicaDB := m.replicaDB.Load(); replicaDB != nil && m.replicaHealthy.Load() {
+		return replicaDB
+	}
+	return m.GetDB()
+}
+
+// checkReplicaHealth periodically pings the read replica and records whether it is usable.
+func (m *DBConnectionManager) checkReplicaHealth(ctx context.Context) {
+	defer close(m.replicaStopped)
+	ticker := time.NewTicker(replicaHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopReplica:
+			return
+		case <-ticker.C:
+			_ = m.pingReplica(ctx)
+		}
+	}
+}
+
+// pingReplica pings the read replica, connecting to it first if it has not been reached yet, and records whether it is usable.
+func (m *DBConnectionManager) pingReplica(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaHealthCheckInterval/2)
+	defer cancel()
+	err := func() error {
+		replicaDB := m.replicaDB.Load()
+		if replicaDB == nil {
+			db, err := m.openReplica(ctx)
+			if err != nil {
+				return err
+			}
+			m.replicaDB.Store(db)
+			return nil
+		}
+		return replicaDB.PingContext(ctx)
+	}()
+	healthy := err == nil
+	if m.replicaHealthy.Swap(healthy) != healthy {
+		if healthy {
+			slog.Info("Read replica is healthy, reads will use it")
+		} else {
+			slog.Warn("Read replica is unhealthy, reads will use the primary", "error", err)
+		}
+	}
+	return err
+}
+
+// Close stops the file watcher and closes the database connections.
 func (m *DBConnectionManager) Close() error {
 	if m.watcher != nil {
 		close(m.stopWatcher)
 		m.watcher.Close()
 	}
 
+	if m.stopReplica != nil {
+		// Wait for the health check so that it does not open a connection after this.
+		close(m.stopReplica)
+		<-m.replicaStopped
+		if replicaDB := m.replicaDB.Load(); replicaDB != nil {
+			if err := replicaDB.Close(); err != nil {
+				slog.Warn("Failed to close read replica connection", "error", err)
+			}
+		}
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -147,7 +245,7 @@ func (m *DBConnectionManager) reloadConnection(ctx context.Context, filePath str
 	slog.Info("PG URL file content updated, reconnecting database")
 
 	// Create new connection first (zero downtime)
-	newDB, err := createConnectionWithTracer(ctx, newURL)
+	newDB, err := createConnectionWithTracer(ctx, newURL, m.tracer)
 	if err != nil {
 		slog.Error("Failed to create new database connection", "error", err)
 		return
@@ -200,13 +298,17 @@ func readURLFromFile(path string) (string, error) {
 	return strings.TrimSpace(string(content)), nil
 }
 
-func createConnectionWithTracer(ctx context.Context, pgURL string) (*sql.DB, error) {
+func createConnectionWithTracer(ctx context.Context, pgURL string, tracer *metadataDBTracer) (*sql.DB, error) {
 	pgxConfig, err := pgx.ParseConfig(pgURL)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse database URL")
 	}
 
-	pgxConfig.Tracer = &metadataDBTracer{}
+	pgxConfig.Tracer = tracer
+	// pgx prepares and caches statements per connection keyed by query text, so the store
+	// does not keep prepared statements of its own; a *sql.Stmt cache would also go stale
+	// when reloadConnection swaps the pool. Setting default_query_exec_mode in the URL
+	// (e.g. simple_protocol behind PgBouncer) overrides this.
 	db := stdlib.OpenDB(*pgxConfig)
 
 	// Validate connection
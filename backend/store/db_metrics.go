@@ -15,4 +15,25 @@ var (
 		},
 		[]string{"operation", "status"},
 	)
+
+	// metadataDBStoreQueryDuration tracks query latency per store method, e.g. "ListDatabaseGroups".
+	// Failed queries are counted with status "error" or "cancelled".
+	metadataDBStoreQueryDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "bytebase_metadata_db_store_query_duration_seconds",
+			Help:    "Duration of queries to Bytebase's internal metadata database by store method",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1.0, 5.0, 10.0, 30.0},
+		},
+		[]string{"method", "status"},
+	)
+
+	// metadataDBStoreQueryRows tracks the number of rows returned or affected per store method.
+	metadataDBStoreQueryRows = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "bytebase_metadata_db_store_query_rows",
+			Help:    "Rows returned or affected by queries to Bytebase's internal metadata database by store method",
+			Buckets: prometheus.ExponentialBuckets(1, 10, 6),
+		},
+		[]string{"method"},
+	)
 )
@@ -3,41 +3,59 @@ package store
 import (
 	"context"
 	"errors"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
-// metadataDBTracer implements pgx.QueryTracer to record query metrics.
-type metadataDBTracer struct{}
+// metadataDBTracer implements pgx.QueryTracer to record query metrics and log slow queries.
+type metadataDBTracer struct {
+	// methodMetrics attributes queries to the Store method issuing them, which costs a call stack walk per query.
+	methodMetrics bool
+	// slowQueryThreshold is the duration above which a query is logged as slow. Zero disables the log.
+	slowQueryThreshold time.Duration
+}
 
 // queryTracerData stores data passed from TraceQueryStart to TraceQueryEnd.
 type queryTracerData struct {
 	startTime time.Time
 	sql       string
+	args      []any
+	// method is the store method issuing the query, empty if the query does not come from one.
+	method string
 }
 
+// storeMethodPrefix is the function name prefix of methods on Store.
+const storeMethodPrefix = "github.com/bytebase/bytebase/backend/store.(*Store)."
+
 // queryTracerCtxKey is the context key for storing query trace data.
 type queryTracerCtxKey struct{}
 
 // TraceQueryStart is called at the beginning of Query, QueryRow, and Exec calls.
-func (*metadataDBTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+func (t *metadataDBTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
 	traceData := queryTracerData{
 		startTime: time.Now(),
 		sql:       data.SQL,
+		args:      data.Args,
+	}
+	if t.methodMetrics {
+		traceData.method = callerStoreMethod()
 	}
 	return context.WithValue(ctx, queryTracerCtxKey{}, traceData)
 }
 
 // TraceQueryEnd is called at the end of Query, QueryRow, and Exec calls.
-func (*metadataDBTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+func (t *metadataDBTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
 	traceData, ok := ctx.Value(queryTracerCtxKey{}).(queryTracerData)
 	if !ok {
 		return
 	}
 
-	duration := time.Since(traceData.startTime).Seconds()
+	elapsed := time.Since(traceData.startTime)
+	duration := elapsed.Seconds()
 	operation := extractQueryOperation(traceData.sql)
 
 	status := "success"
@@ -51,6 +69,66 @@ func (*metadataDBTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pg
 	}
 
 	metadataDBQueryDuration.WithLabelValues(operation, status).Observe(duration)
+	if traceData.method != "" {
+		metadataDBStoreQueryDuration.WithLabelValues(traceData.method, status).Observe(duration)
+		if data.Err == nil {
+			metadataDBStoreQueryRows.WithLabelValues(traceData.method).Observe(float64(data.CommandTag.RowsAffected()))
+		}
+	}
+	t.logSlowQuery(traceData, elapsed, data.CommandTag.RowsAffected(), data.Err)
+}
+
+// storeMethodByPC caches the result of storeMethodAt, since symbolizing frames dominates the cost of callerStoreMethod.
+var storeMethodByPC sync.Map
+
+// callerStoreMethod returns the name of the outermost Store method on the current call stack,
+// so queries issued by shared helpers such as listDatabaseGroupImpl are attributed to the public method.
+// pgx calls the tracer synchronously on the goroutine that issued the query, so this covers queries
+// made through the accessors as well as those inside transactions.
+func callerStoreMethod() string {
+	var pcs [64]uintptr
+	n := runtime.Callers(3, pcs[:])
+	var method string
+	for _, pc := range pcs[:n] {
+		if m := storeMethodAt(pc); m != "" {
+			method = m
+		}
+	}
+	return method
+}
+
+// storeMethodAt returns the outermost Store method among the frames at the return PC pc,
+// which are several if calls were inlined, or "" if there is none.
+func storeMethodAt(pc uintptr) string {
+	if v, ok := storeMethodByPC.Load(pc); ok {
+		return v.(string)
+	}
+	var method string
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		if m, ok := parseStoreMethod(frame.Function); ok {
+			method = m
+		}
+		if !more {
+			break
+		}
+	}
+	storeMethodByPC.Store(pc, method)
+	return method
+}
+
+// parseStoreMethod extracts the method name from a fully qualified Store method or closure name,
+// e.g. "github.com/bytebase/bytebase/backend/store.(*Store).UpdateGroup.func1" returns "UpdateGroup".
+func parseStoreMethod(function string) (string, bool) {
+	method, ok := strings.CutPrefix(function, storeMethodPrefix)
+	if !ok {
+		return "", false
+	}
+	if i := strings.IndexByte(method, '.'); i >= 0 {
+		method = method[:i]
+	}
+	return method, method != ""
 }
 
 // extractQueryOperation extracts the SQL operation type from a query string.
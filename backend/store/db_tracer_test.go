@@ -3,10 +3,14 @@ package store
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,3 +96,85 @@ func TestMetadataDBTracer_TraceQueryEnd_MissingContext(t *testing.T) {
 		tracer.TraceQueryEnd(ctx, nil, endData)
 	})
 }
+
+func TestParseStoreMethod(t *testing.T) {
+	tests := []struct {
+		function string
+		method   string
+		ok       bool
+	}{
+		{"github.com/bytebase/bytebase/backend/store.(*Store).ListGroups", "ListGroups", true},
+		{"github.com/bytebase/bytebase/backend/store.(*Store).UpdateGroup.func1", "UpdateGroup", true},
+		{"github.com/bytebase/bytebase/backend/store.(*Store).listDatabaseGroupImpl", "listDatabaseGroupImpl", true},
+		{"github.com/bytebase/bytebase/backend/store.callerStoreMethod", "", false},
+		{"github.com/bytebase/bytebase/backend/api/v1.(*IssueService).GetIssue", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		method, ok := parseStoreMethod(tt.function)
+		assert.Equal(t, tt.ok, ok, tt.function)
+		assert.Equal(t, tt.method, method, tt.function)
+	}
+}
+
+// traceTestQuery mimics a store method issuing a query through pgx.
+func (*Store) traceTestQuery(tracer *metadataDBTracer, err error) {
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 3"), Err: err})
+}
+
+func TestMetadataDBTracer_StoreMethodMetrics(t *testing.T) {
+	tracer := &metadataDBTracer{methodMetrics: true}
+	s := &Store{}
+
+	s.traceTestQuery(tracer, nil)
+	s.traceTestQuery(tracer, errors.New("some error"))
+
+	// One duration series per status, rows are only recorded for successful queries.
+	assert.Equal(t, 2, testutil.CollectAndCount(metadataDBStoreQueryDuration))
+	expected := `
+# HELP bytebase_metadata_db_store_query_rows Rows returned or affected by queries to Bytebase's internal metadata database by store method
+# TYPE bytebase_metadata_db_store_query_rows histogram
+bytebase_metadata_db_store_query_rows_bucket{method="traceTestQuery",le="1"} 0
+bytebase_metadata_db_store_query_rows_bucket{method="traceTestQuery",le="10"} 1
+bytebase_metadata_db_store_query_rows_bucket{method="traceTestQuery",le="100"} 1
+bytebase_metadata_db_store_query_rows_bucket{method="traceTestQuery",le="1000"} 1
+bytebase_metadata_db_store_query_rows_bucket{method="traceTestQuery",le="10000"} 1
+bytebase_metadata_db_store_query_rows_bucket{method="traceTestQuery",le="100000"} 1
+bytebase_metadata_db_store_query_rows_bucket{method="traceTestQuery",le="+Inf"} 1
+bytebase_metadata_db_store_query_rows_sum{method="traceTestQuery"} 3
+bytebase_metadata_db_store_query_rows_count{method="traceTestQuery"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(metadataDBStoreQueryRows, strings.NewReader(expected)))
+}
+
+func TestMetadataDBTracer_MethodMetricsDisabled(t *testing.T) {
+	tracer := &metadataDBTracer{}
+	ctx := (&Store{}).traceTestStart(tracer)
+	traceData, ok := ctx.Value(queryTracerCtxKey{}).(queryTracerData)
+	assert.True(t, ok)
+	assert.Empty(t, traceData.method)
+
+	tracer.methodMetrics = true
+	traceData = (&Store{}).traceTestStart(tracer).Value(queryTracerCtxKey{}).(queryTracerData)
+	assert.Equal(t, "traceTestStart", traceData.method)
+}
+
+// traceTestStart mimics a store method starting a query through pgx.
+func (*Store) traceTestStart(tracer *metadataDBTracer) context.Context {
+	return tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+}
+
+func BenchmarkMetadataDBTracer(b *testing.B) {
+	for _, methodMetrics := range []bool{false, true} {
+		b.Run(fmt.Sprintf("methodMetrics=%t", methodMetrics), func(b *testing.B) {
+			tracer := &metadataDBTracer{methodMetrics: methodMetrics}
+			s := &Store{}
+			b.ReportAllocs()
+			for b.Loop() {
+				s.traceTestQuery(tracer, nil)
+			}
+		})
+	}
+}
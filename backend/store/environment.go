@@ -38,7 +38,7 @@ func (s *Store) CheckDatabaseUseEnvironment(ctx context.Context, id string) (boo
 		return false, errors.Wrapf(err, "failed to build sql")
 	}
 
-	if err := s.GetDB().QueryRowContext(ctx, sql, args...).Scan(&exists); err != nil {
+	if err := s.queryRowContext(ctx, sql, args...).Scan(&exists); err != nil {
 		return false, errors.Wrapf(err, "failed to check if databases uses environment %q", id)
 	}
 
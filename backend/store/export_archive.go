@@ -61,7 +61,7 @@ func (s *Store) ListExportArchives(ctx context.Context, find *FindExportArchiveM
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +124,7 @@ func (s *Store) CreateExportArchive(ctx context.Context, create *ExportArchiveMe
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -147,7 +147,7 @@ func (s *Store) DeleteExportArchive(ctx context.Context, uid int) error {
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -163,6 +163,8 @@ func (s *Store) DeleteExportArchive(ctx context.Context, uid int) error {
 // DeleteExpiredExportArchives deletes export archives older than the specified retention period.
 // Returns the number of archives deleted.
 func (s *Store) DeleteExpiredExportArchives(ctx context.Context, retentionPeriod time.Duration) (int64, error) {
+	ctx, cancel := s.withLongQueryTimeout(ctx)
+	defer cancel()
 	cutoffTime := time.Now().Add(-retentionPeriod)
 
 	q := qb.Q().Space("DELETE FROM export_archive WHERE created_at < ?", cutoffTime)
@@ -171,7 +173,7 @@ func (s *Store) DeleteExpiredExportArchives(ctx context.Context, retentionPeriod
 		return 0, errors.Wrapf(err, "failed to build sql")
 	}
 
-	result, err := s.GetDB().ExecContext(ctx, query, args...)
+	result, err := s.execContext(ctx, query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -57,7 +57,7 @@ func (s *Store) GetGroup(ctx context.Context, find *FindGroupMessage) (*GroupMes
 		}
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +81,7 @@ func (s *Store) GetGroup(ctx context.Context, find *FindGroupMessage) (*GroupMes
 
 // ListGroups list all groups.
 func (s *Store) ListGroups(ctx context.Context, find *FindGroupMessage) ([]*GroupMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +101,7 @@ func (s *Store) ListGroups(ctx context.Context, find *FindGroupMessage) ([]*Grou
 	return groups, nil
 }
 
-func (*Store) listGroupImpl(ctx context.Context, txn *sql.Tx, find *FindGroupMessage) ([]*GroupMessage, error) {
+func (*Store) listGroupImpl(ctx context.Context, txn *trackedTx, find *FindGroupMessage) ([]*GroupMessage, error) {
 	with := qb.Q()
 	from := qb.Q().Space("user_group")
 	where := qb.Q().Space("TRUE")
@@ -228,20 +228,12 @@ func (s *Store) CreateGroup(ctx context.Context, create *GroupMessage) (*GroupMe
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to begin tx")
-	}
-	defer tx.Rollback()
-
-	if err := tx.QueryRowContext(ctx, query, args...).Scan(&create.ID); err != nil {
+	if err := s.RunInTx(ctx, func(tx *trackedTx) error {
+		return tx.QueryRowContext(ctx, query, args...).Scan(&create.ID)
+	}); err != nil {
 		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, errors.Wrap(err, "failed to commit")
-	}
-
 	s.groupCache.Add(create.Email, create)
 	return create, nil
 }
@@ -283,34 +275,27 @@ func (s *Store) UpdateGroup(ctx context.Context, patch *UpdateGroupMessage) (*Gr
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to begin transaction")
-	}
-	defer tx.Rollback()
-
 	var group GroupMessage
-	var payload []byte
-
-	if err := tx.QueryRowContext(ctx, query, args...).Scan(
-		&group.ID,
-		&group.Email,
-		&group.Title,
-		&group.Description,
-		&payload,
-	); err != nil {
-		return nil, err
-	}
-
-	groupPayload := storepb.GroupPayload{}
-	if err := common.ProtojsonUnmarshaler.Unmarshal(payload, &groupPayload); err != nil {
+	if err := s.RunInTx(ctx, func(tx *trackedTx) error {
+		var payload []byte
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(
+			&group.ID,
+			&group.Email,
+			&group.Title,
+			&group.Description,
+			&payload,
+		); err != nil {
+			return err
+		}
+		groupPayload := storepb.GroupPayload{}
+		if err := common.ProtojsonUnmarshaler.Unmarshal(payload, &groupPayload); err != nil {
+			return err
+		}
+		group.Payload = &groupPayload
+		return nil
+	}); err != nil {
 		return nil, err
 	}
-	group.Payload = &groupPayload
-
-	if err := tx.Commit(); err != nil {
-		return nil, errors.Wrap(err, "failed to commit transaction")
-	}
 
 	s.groupCache.Add(group.Email, &group)
 	return &group, nil
@@ -318,12 +303,6 @@ func (s *Store) UpdateGroup(ctx context.Context, patch *UpdateGroupMessage) (*Gr
 
 // DeleteGroup deletes a group.
 func (s *Store) DeleteGroup(ctx context.Context, id string) error {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		return errors.Wrap(err, "failed to begin transaction")
-	}
-	defer tx.Rollback()
-
 	q := qb.Q().Space("DELETE FROM user_group WHERE id = ? RETURNING email", id)
 	query, args, err := q.ToSQL()
 	if err != nil {
@@ -331,14 +310,12 @@ func (s *Store) DeleteGroup(ctx context.Context, id string) error {
 	}
 
 	var email string
-	if err := tx.QueryRowContext(ctx, query, args...).Scan(&email); err != nil {
+	if err := s.RunInTx(ctx, func(tx *trackedTx) error {
+		return tx.QueryRowContext(ctx, query, args...).Scan(&email)
+	}); err != nil {
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.Wrap(err, "failed to commit transaction")
-	}
-
 	s.groupCache.Remove(email)
 	return nil
 }
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateGroupRollsBackMalformedPayloadWithTestcontainer(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	s := newTestcontainerStore(t)
+	// The payload is valid JSON but not a valid GroupPayload.
+	_, err := s.execContext(ctx, `INSERT INTO user_group (id, email, name, payload) VALUES ('g1', 'dev@example.com', 'Dev', '{"members": "everyone"}')`)
+	a.NoError(err)
+
+	title := "Developers"
+	_, err = s.UpdateGroup(ctx, &UpdateGroupMessage{ID: "g1", Title: &title})
+	a.Error(err)
+
+	// The update is rolled back and nothing is cached.
+	var name string
+	a.NoError(s.queryRowContext(ctx, "SELECT name FROM user_group WHERE id = 'g1'").Scan(&name))
+	a.Equal("Dev", name)
+	_, ok := s.groupCache.Get("dev@example.com")
+	a.False(ok)
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+// healthCheckTimeout bounds each ping of a health check, so a wedged pool fails the check instead of hanging the probe.
+const healthCheckTimeout = 3 * time.Second
+
+// HealthStatus is the health of the metadata database connections.
+// Only Healthy and ConsecutiveFailures may be exposed publicly; the rest reveals details of the metadata database.
+type HealthStatus struct {
+	// Healthy is whether the primary is reachable. An unhealthy replica does not fail the check because reads fall back to the primary.
+	Healthy bool
+	// ConsecutiveFailures is the number of failed checks in a row, to tell a blip from a sustained outage.
+	ConsecutiveFailures int64
+	Primary             DBHealth
+	// Replica is nil if no read replica is configured.
+	Replica *DBHealth
+}
+
+// DBHealth is the health and pool statistics of one database connection pool.
+type DBHealth struct {
+	Healthy         bool
+	Error           string
+	OpenConnections int
+	InUse           int
+	Idle            int
+	WaitCount       int64
+	WaitDuration    time.Duration
+}
+
+// HealthCheck pings the primary, and the read replica if one is configured, and reports their pool statistics.
+// The store is unhealthy once it is shutting down.
+func (s *Store) HealthCheck(ctx context.Context) *HealthStatus {
+	if err := s.startOperation(); err != nil {
+		return &HealthStatus{
+			Healthy:             false,
+			ConsecutiveFailures: s.healthCheckFailures.Add(1),
+			Primary:             DBHealth{Error: err.Error()},
+		}
+	}
+	defer s.inflight.Done()
+
+	status := &HealthStatus{
+		Primary: checkDBHealth(ctx, "primary", s.dbConnManager.GetDB()),
+	}
+	if replicaDB := s.dbConnManager.replicaDB.Load(); replicaDB != nil {
+		replica := checkDBHealth(ctx, "replica", replicaDB)
+		status.Replica = &replica
+	}
+
+	status.Healthy = status.Primary.Healthy
+	if status.Healthy {
+		s.healthCheckFailures.Store(0)
+	} else {
+		status.ConsecutiveFailures = s.healthCheckFailures.Add(1)
+	}
+	return status
+}
+
+func checkDBHealth(ctx context.Context, name string, db *sql.DB) DBHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	health := DBHealth{Healthy: true}
+	err := db.PingContext(ctx)
+	stats := db.Stats()
+	health.OpenConnections = stats.OpenConnections
+	health.InUse = stats.InUse
+	health.Idle = stats.Idle
+	health.WaitCount = stats.WaitCount
+	health.WaitDuration = stats.WaitDuration
+	if err != nil {
+		health.Healthy = false
+		health.Error = err.Error()
+		slog.Warn("Metadata database health check failed", slog.String("database", name), log.BBError(err),
+			slog.Int("openConnections", stats.OpenConnections), slog.Int("inUse", stats.InUse), slog.Int64("waitCount", stats.WaitCount))
+	}
+	return health
+}
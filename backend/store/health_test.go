@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	_, db := newFakeDB(t)
+	s := newTestStore(db)
+
+	status := s.HealthCheck(ctx)
+	a.True(status.Healthy)
+	a.True(status.Primary.Healthy)
+	a.Empty(status.Primary.Error)
+	a.Equal(1, status.Primary.OpenConnections)
+	a.Nil(status.Replica)
+	a.Zero(status.ConsecutiveFailures)
+}
+
+func TestHealthCheckClosedDatabase(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	_, db := newFakeDB(t)
+	s := newTestStore(db)
+	a.NoError(db.Close())
+
+	status := s.HealthCheck(ctx)
+	a.False(status.Healthy)
+	a.False(status.Primary.Healthy)
+	a.Contains(status.Primary.Error, "database is closed")
+	a.EqualValues(1, status.ConsecutiveFailures)
+
+	status = s.HealthCheck(ctx)
+	a.EqualValues(2, status.ConsecutiveFailures)
+}
+
+func TestHealthCheckReplica(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	_, primaryDB := newFakeDB(t)
+	_, replicaDB := newFakeDB(t)
+	s := &Store{dbConnManager: &DBConnectionManager{db: primaryDB}}
+	s.dbConnManager.replicaDB.Store(replicaDB)
+	a.NoError(replicaDB.Close())
+
+	// A failing replica is reported but does not fail the check.
+	status := s.HealthCheck(ctx)
+	a.True(status.Healthy)
+	a.NotNil(status.Replica)
+	a.False(status.Replica.Healthy)
+	a.Zero(status.ConsecutiveFailures)
+}
+
+func TestHealthCheckAfterClose(t *testing.T) {
+	a := require.New(t)
+	_, db := newFakeDB(t)
+	s := newTestStore(db)
+	a.NoError(s.Close())
+
+	status := s.HealthCheck(context.Background())
+	a.False(status.Healthy)
+	a.EqualValues(1, status.ConsecutiveFailures)
+}
@@ -51,7 +51,7 @@ type UpdateIdentityProviderMessage struct {
 
 // CreateIdentityProvider creates an identity provider.
 func (s *Store) CreateIdentityProvider(ctx context.Context, create *IdentityProviderMessage) (*IdentityProviderMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +105,7 @@ func (s *Store) GetIdentityProvider(ctx context.Context, find *FindIdentityProvi
 		}
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +132,7 @@ func (s *Store) GetIdentityProvider(ctx context.Context, find *FindIdentityProvi
 
 // ListIdentityProviders lists identity providers.
 func (s *Store) ListIdentityProviders(ctx context.Context, find *FindIdentityProviderMessage) ([]*IdentityProviderMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +154,7 @@ func (s *Store) ListIdentityProviders(ctx context.Context, find *FindIdentityPro
 
 // UpdateIdentityProvider updates an identity provider.
 func (s *Store) UpdateIdentityProvider(ctx context.Context, patch *UpdateIdentityProviderMessage) (*IdentityProviderMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +172,7 @@ func (s *Store) UpdateIdentityProvider(ctx context.Context, patch *UpdateIdentit
 	return identityProvider, nil
 }
 
-func (*Store) updateIdentityProviderImpl(ctx context.Context, txn *sql.Tx, patch *UpdateIdentityProviderMessage) (*IdentityProviderMessage, error) {
+func (*Store) updateIdentityProviderImpl(ctx context.Context, txn *trackedTx, patch *UpdateIdentityProviderMessage) (*IdentityProviderMessage, error) {
 	set := qb.Q()
 	if v := patch.Title; v != nil {
 		set.Comma("name = ?", *v)
@@ -229,7 +229,7 @@ func (*Store) updateIdentityProviderImpl(ctx context.Context, txn *sql.Tx, patch
 	return identityProvider, nil
 }
 
-func (*Store) listIdentityProvidersImpl(ctx context.Context, txn *sql.Tx, find *FindIdentityProviderMessage) ([]*IdentityProviderMessage, error) {
+func (*Store) listIdentityProvidersImpl(ctx context.Context, txn *trackedTx, find *FindIdentityProviderMessage) ([]*IdentityProviderMessage, error) {
 	q := qb.Q().Space(`
 		SELECT
 			resource_id,
@@ -284,7 +284,7 @@ func (*Store) listIdentityProvidersImpl(ctx context.Context, txn *sql.Tx, find *
 }
 
 func (s *Store) DeleteIdentityProvider(ctx context.Context, resourceID string) error {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -78,7 +78,7 @@ func (s *Store) GetInstanceV2(ctx context.Context, find *FindInstanceMessage) (*
 
 // ListInstancesV2 lists all instance.
 func (s *Store) ListInstancesV2(ctx context.Context, find *FindInstanceMessage) ([]*InstanceMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +105,7 @@ func (s *Store) CreateInstanceV2(ctx context.Context, instanceCreate *InstanceMe
 		return nil, err
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +202,7 @@ func (s *Store) UpdateInstanceV2(ctx context.Context, patch *UpdateInstanceMessa
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +224,7 @@ func (s *Store) UpdateInstanceV2(ctx context.Context, patch *UpdateInstanceMessa
 	return nil, nil
 }
 
-func (s *Store) listInstanceImplV2(ctx context.Context, txn *sql.Tx, find *FindInstanceMessage) ([]*InstanceMessage, error) {
+func (s *Store) listInstanceImplV2(ctx context.Context, txn *trackedTx, find *FindInstanceMessage) ([]*InstanceMessage, error) {
 	where := qb.Q().Space("TRUE")
 	from := qb.Q().Space("instance")
 	if filterQ := find.FilterQ; filterQ != nil {
@@ -320,7 +320,7 @@ func (s *Store) GetActivatedInstanceCount(ctx context.Context) (int, error) {
 		return 0, errors.Wrapf(err, "failed to build sql")
 	}
 	var count int
-	if err := s.GetDB().QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+	if err := s.queryRowContext(ctx, query, args...).Scan(&count); err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -548,7 +548,9 @@ func (s *Store) HasSampleInstances(ctx context.Context) (bool, error) {
 // - Test cleanup
 // Following AIP-164/165, this only works on instances where deleted = TRUE.
 func (s *Store) DeleteInstance(ctx context.Context, resourceID string) error {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	ctx, cancel := s.withLongQueryTimeout(ctx)
+	defer cancel()
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
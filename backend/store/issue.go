@@ -17,6 +17,9 @@ import (
 	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
 )
 
+// ErrIssueApprovalChanged is returned by UpdateIssueV2 if the issue approvers changed since they were read.
+var ErrIssueApprovalChanged = errors.New("issue approval has changed")
+
 var getSegmenter func() *gse.Segmenter
 
 func init() {
@@ -62,6 +65,9 @@ type UpdateIssueMessage struct {
 	// PayloadUpsert upserts the presented top-level keys.
 	PayloadUpsert *storepb.Issue
 	RemoveLabels  bool
+	// ApproverCount, if set, only applies the update if the issue still has that many approvers.
+	// It keeps concurrent approvals from overwriting each other, since approvers are written back as a whole.
+	ApproverCount *int
 }
 
 // FindIssueMessage is the message to find issues.
@@ -169,7 +175,7 @@ func (s *Store) CreateIssueV2(ctx context.Context, create *IssueMessage, creator
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -232,21 +238,39 @@ func (s *Store) UpdateIssueV2(ctx context.Context, uid int, patch *UpdateIssueMe
 	}
 
 	q := qb.Q().Space("UPDATE issue SET ? WHERE id = ?", set, uid)
+	if v := patch.ApproverCount; v != nil {
+		q.Space("AND jsonb_array_length(COALESCE(payload->'approval'->'approvers', '[]'::JSONB)) = ?", *v)
+	}
 
 	query, args, err := q.ToSQL()
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
 		return nil, err
 	}
+	if patch.ApproverCount != nil {
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected == 0 {
+			// The cached issue is stale if another server or request approved it.
+			s.issueCache.Remove(uid)
+			if oldIssue.PipelineUID != nil {
+				s.issueByPipelineCache.Remove(*oldIssue.PipelineUID)
+			}
+			return nil, ErrIssueApprovalChanged
+		}
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, err
@@ -397,7 +421,7 @@ func (s *Store) ListIssueV2(ctx context.Context, find *FindIssueMessage) ([]*Iss
 	}
 
 	var issues []*IssueMessage
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -501,7 +525,7 @@ func (s *Store) BatchUpdateIssueStatuses(ctx context.Context, issueUIDs []int, s
 	}
 	var infos []issueInfo
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -549,6 +573,47 @@ func (s *Store) BatchUpdateIssueStatuses(ctx context.Context, issueUIDs []int, s
 	return nil
 }
 
+// CountPendingApprovalIssues returns the number of open issues waiting for approval by risk level.
+// An issue is waiting if its approval template is found, no approver has rejected it and some steps are not approved yet.
+func (s *Store) CountPendingApprovalIssues(ctx context.Context) (map[storepb.RiskLevel]int, error) {
+	q := qb.Q().Space(`
+		SELECT
+			COALESCE(payload->>'riskLevel', ''),
+			COUNT(*)
+		FROM issue
+		WHERE status = ?
+			AND COALESCE((payload->'approval'->>'approvalFindingDone')::BOOLEAN, FALSE)
+			AND COALESCE(payload->'approval'->>'approvalFindingError', '') = ''
+			AND payload->'approval'->'approvalTemplate' IS NOT NULL
+			AND jsonb_array_length(COALESCE(payload->'approval'->'approvers', '[]'::JSONB)) < jsonb_array_length(COALESCE(payload->'approval'->'approvalTemplate'->'flow'->'roles', '[]'::JSONB))
+			AND NOT COALESCE(payload->'approval'->'approvers', '[]'::JSONB) @> ?::JSONB
+		GROUP BY 1
+	`, storepb.Issue_OPEN.String(), `[{"status": "REJECTED"}]`)
+	query, args, err := q.ToSQL()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build sql")
+	}
+
+	rows, err := s.readQueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer rows.Close()
+	counts := map[storepb.RiskLevel]int{}
+	for rows.Next() {
+		var riskLevel string
+		var count int
+		if err := rows.Scan(&riskLevel, &count); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan")
+		}
+		counts[storepb.RiskLevel(storepb.RiskLevel_value[riskLevel])] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to scan rows")
+	}
+	return counts, nil
+}
+
 func getTSVector(text string) string {
 	seg := getSegmenter()
 	parts := seg.CutTrim(text)
@@ -583,10 +648,13 @@ func getTSQuery(text string) string {
 }
 
 func (s *Store) BackfillIssueTSVector(ctx context.Context) error {
+	// The backfill updates every issue in one transaction.
+	ctx, cancel := s.withLongQueryTimeout(ctx)
+	defer cancel()
 	chunkSize := 50
 	offset := 0
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+)
+
+func TestCountPendingApprovalIssues(t *testing.T) {
+	a := require.New(t)
+	fake, db := newFakeDB(t)
+	fake.result = func(string) *fakeRows {
+		return &fakeRows{
+			columns: []string{"risk_level", "count"},
+			values: [][]driver.Value{
+				{"HIGH", int64(3)},
+				{"LOW", int64(1)},
+				// Issues created before risk levels were recorded.
+				{"", int64(2)},
+			},
+		}
+	}
+	s := newTestStore(db)
+
+	counts, err := s.CountPendingApprovalIssues(context.Background())
+	a.NoError(err)
+	a.Equal(map[storepb.RiskLevel]int{
+		storepb.RiskLevel_HIGH:                   3,
+		storepb.RiskLevel_LOW:                    1,
+		storepb.RiskLevel_RISK_LEVEL_UNSPECIFIED: 2,
+	}, counts)
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/common"
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+)
+
+func TestUpdateIssueV2ApproverCountWithTestcontainer(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	s := newTestcontainerStore(t)
+
+	createIssue := func() *IssueMessage {
+		issue, err := s.CreateIssueV2(ctx, &IssueMessage{
+			Project: &ProjectMessage{ResourceID: "default"},
+			Title:   "Approve me",
+			Type:    storepb.Issue_DATABASE_CHANGE,
+			Payload: &storepb.Issue{},
+		}, common.SystemBotID)
+		a.NoError(err)
+		return issue
+	}
+	// approve writes the given approvers back if the issue still has readCount approvers, like ApproveIssue does.
+	approve := func(uid int, readCount int, principalIDs ...int32) error {
+		approval := &storepb.IssuePayloadApproval{}
+		for _, id := range principalIDs {
+			approval.Approvers = append(approval.Approvers, &storepb.IssuePayloadApproval_Approver{
+				Status:      storepb.IssuePayloadApproval_Approver_APPROVED,
+				PrincipalId: id,
+			})
+		}
+		_, err := s.UpdateIssueV2(ctx, uid, &UpdateIssueMessage{
+			PayloadUpsert: &storepb.Issue{Approval: approval},
+			ApproverCount: &readCount,
+		})
+		return err
+	}
+	approvers := func(uid int) []int32 {
+		issue, err := s.GetIssueV2(ctx, &FindIssueMessage{UID: &uid})
+		a.NoError(err)
+		var ids []int32
+		for _, approver := range issue.Payload.GetApproval().GetApprovers() {
+			ids = append(ids, approver.PrincipalId)
+		}
+		return ids
+	}
+
+	issue := createIssue()
+	a.NoError(approve(issue.UID, 0, 101))
+	// An approval based on a stale read is not applied.
+	a.ErrorIs(approve(issue.UID, 0, 102), ErrIssueApprovalChanged)
+	a.Equal([]int32{101}, approvers(issue.UID))
+	// The next step can be approved once the first one is, even by the same user.
+	a.NoError(approve(issue.UID, 1, 101, 101))
+	a.Equal([]int32{101, 101}, approvers(issue.UID))
+
+	// Of two concurrent approvals based on the same read, exactly one is applied.
+	issue = createIssue()
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = approve(issue.UID, 0, int32(101+i))
+		}()
+	}
+	wg.Wait()
+	a.Len(approvers(issue.UID), 1)
+	if errs[0] == nil {
+		a.ErrorIs(errs[1], ErrIssueApprovalChanged)
+	} else {
+		a.ErrorIs(errs[0], ErrIssueApprovalChanged)
+		a.NoError(errs[1])
+	}
+}
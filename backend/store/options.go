@@ -0,0 +1,183 @@
+package store
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CacheSizes is the capacity of each store cache.
+// A zero value keeps the default capacity of that cache.
+type CacheSizes struct {
+	User             int
+	Instance         int
+	Database         int
+	Project          int
+	Policy           int
+	Issue            int
+	Pipeline         int
+	Setting          int
+	IdentityProvider int
+	DatabaseGroup    int
+	Role             int
+	Group            int
+	Sheet            int
+	SheetStatement   int
+	DBMetadata       int
+}
+
+var defaultCacheSizes = CacheSizes{
+	User:             32768,
+	Instance:         32768,
+	Database:         32768,
+	Project:          32768,
+	Policy:           128,
+	Issue:            256,
+	Pipeline:         256,
+	Setting:          64,
+	IdentityProvider: 4,
+	DatabaseGroup:    1024,
+	Role:             64,
+	Group:            1024,
+	Sheet:            64,
+	SheetStatement:   10,
+	DBMetadata:       128,
+}
+
+// withDefaults returns the sizes with every zero value replaced by its default.
+func (c CacheSizes) withDefaults() CacheSizes {
+	orDefault := func(v, d int) int {
+		if v == 0 {
+			return d
+		}
+		return v
+	}
+	d := defaultCacheSizes
+	return CacheSizes{
+		User:             orDefault(c.User, d.User),
+		Instance:         orDefault(c.Instance, d.Instance),
+		Database:         orDefault(c.Database, d.Database),
+		Project:          orDefault(c.Project, d.Project),
+		Policy:           orDefault(c.Policy, d.Policy),
+		Issue:            orDefault(c.Issue, d.Issue),
+		Pipeline:         orDefault(c.Pipeline, d.Pipeline),
+		Setting:          orDefault(c.Setting, d.Setting),
+		IdentityProvider: orDefault(c.IdentityProvider, d.IdentityProvider),
+		DatabaseGroup:    orDefault(c.DatabaseGroup, d.DatabaseGroup),
+		Role:             orDefault(c.Role, d.Role),
+		Group:            orDefault(c.Group, d.Group),
+		Sheet:            orDefault(c.Sheet, d.Sheet),
+		SheetStatement:   orDefault(c.SheetStatement, d.SheetStatement),
+		DBMetadata:       orDefault(c.DBMetadata, d.DBMetadata),
+	}
+}
+
+// ParseCacheSizes parses cache capacities keyed by cache name, e.g. {"database": 65536, "database-metadata": 256}.
+func ParseCacheSizes(sizes map[string]int) (CacheSizes, error) {
+	var c CacheSizes
+	fields := map[string]*int{
+		"user":              &c.User,
+		"instance":          &c.Instance,
+		"database":          &c.Database,
+		"project":           &c.Project,
+		"policy":            &c.Policy,
+		"issue":             &c.Issue,
+		"pipeline":          &c.Pipeline,
+		"setting":           &c.Setting,
+		"identity-provider": &c.IdentityProvider,
+		"database-group":    &c.DatabaseGroup,
+		"role":              &c.Role,
+		"group":             &c.Group,
+		"sheet":             &c.Sheet,
+		"sheet-statement":   &c.SheetStatement,
+		"database-metadata": &c.DBMetadata,
+	}
+	for name, size := range sizes {
+		field, ok := fields[name]
+		if !ok {
+			return CacheSizes{}, errors.Errorf("unknown cache %q", name)
+		}
+		*field = size
+	}
+	return c, nil
+}
+
+// Option configures a Store.
+type Option func(*options)
+
+type options struct {
+	pgReplicaURL       string
+	enableCache        bool
+	cacheSizes         CacheSizes
+	queryTimeout       time.Duration
+	drainTimeout       time.Duration
+	methodMetrics      bool
+	slowQueryThreshold time.Duration
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		enableCache:        true,
+		cacheSizes:         defaultCacheSizes,
+		queryTimeout:       defaultQueryTimeout,
+		drainTimeout:       defaultDrainTimeout,
+		methodMetrics:      true,
+		slowQueryThreshold: defaultSlowQueryThreshold,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithReadReplica sets the URL of a read replica serving lag-tolerant list queries.
+func WithReadReplica(pgReplicaURL string) Option {
+	return func(o *options) {
+		o.pgReplicaURL = pgReplicaURL
+	}
+}
+
+// WithCacheEnabled sets whether cached objects are served from the store caches. It defaults to true.
+// Caches must be disabled when several servers share the metadata database, since they are not invalidated across servers.
+func WithCacheEnabled(enabled bool) Option {
+	return func(o *options) {
+		o.enableCache = enabled
+	}
+}
+
+// WithCacheSizes overrides the capacity of the store caches. Zero sizes keep their defaults.
+func WithCacheSizes(sizes CacheSizes) Option {
+	return func(o *options) {
+		o.cacheSizes = sizes.withDefaults()
+	}
+}
+
+// WithQueryTimeout sets the timeout for queries whose context has no deadline. Zero disables it.
+// Known-heavy operations get a multiple of it.
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.queryTimeout = timeout
+	}
+}
+
+// WithDrainTimeout sets how long Close waits for in-flight operations. Zero closes the connections right away.
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.drainTimeout = timeout
+	}
+}
+
+// WithMethodMetrics sets whether query metrics are recorded per Store method. It defaults to true.
+// Disabling it saves a call stack walk per query.
+func WithMethodMetrics(enabled bool) Option {
+	return func(o *options) {
+		o.methodMetrics = enabled
+	}
+}
+
+// WithSlowQueryThreshold sets the duration above which queries are logged as slow. Zero disables the log.
+func WithSlowQueryThreshold(threshold time.Duration) Option {
+	return func(o *options) {
+		o.slowQueryThreshold = threshold
+	}
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
+)
+
+func TestNewOptions(t *testing.T) {
+	a := require.New(t)
+
+	o := newOptions()
+	a.True(o.enableCache)
+	a.Equal(defaultCacheSizes, o.cacheSizes)
+	a.Equal(defaultQueryTimeout, o.queryTimeout)
+	a.Equal(defaultDrainTimeout, o.drainTimeout)
+	a.True(o.methodMetrics)
+	a.Equal(defaultSlowQueryThreshold, o.slowQueryThreshold)
+	a.Empty(o.pgReplicaURL)
+
+	o = newOptions(
+		WithReadReplica("postgres://replica"),
+		WithCacheEnabled(false),
+		WithCacheSizes(CacheSizes{Setting: 1, DBMetadata: 4096}),
+		WithQueryTimeout(time.Second),
+		WithMethodMetrics(false),
+		WithSlowQueryThreshold(0),
+	)
+	a.False(o.enableCache)
+	a.False(o.methodMetrics)
+	a.Equal("postgres://replica", o.pgReplicaURL)
+	a.Equal(time.Second, o.queryTimeout)
+	a.Zero(o.slowQueryThreshold)
+	a.Equal(1, o.cacheSizes.Setting)
+	a.Equal(4096, o.cacheSizes.DBMetadata)
+	// Sizes left unset keep their defaults.
+	a.Equal(defaultCacheSizes.User, o.cacheSizes.User)
+	a.Equal(defaultCacheSizes.SheetStatement, o.cacheSizes.SheetStatement)
+}
+
+func TestInitCaches(t *testing.T) {
+	a := require.New(t)
+
+	s := &Store{}
+	a.NoError(s.initCaches(CacheSizes{Setting: 1}.withDefaults()))
+	s.settingCache.Add(storepb.SettingName_BRANDING_LOGO, &SettingMessage{})
+	s.settingCache.Add(storepb.SettingName_WORKSPACE_ID, &SettingMessage{})
+	a.Equal(1, s.settingCache.Len())
+	a.False(s.settingCache.Contains(storepb.SettingName_BRANDING_LOGO))
+	a.True(s.settingCache.Contains(storepb.SettingName_WORKSPACE_ID))
+
+	err := (&Store{}).initCaches(CacheSizes{Sheet: -1}.withDefaults())
+	a.ErrorContains(err, "invalid sheet cache size -1")
+}
+
+func TestParseCacheSizes(t *testing.T) {
+	a := require.New(t)
+
+	sizes, err := ParseCacheSizes(map[string]int{"database": 65536, "database-metadata": 256})
+	a.NoError(err)
+	a.Equal(CacheSizes{Database: 65536, DBMetadata: 256}, sizes)
+
+	sizes, err = ParseCacheSizes(nil)
+	a.NoError(err)
+	a.Equal(CacheSizes{}, sizes)
+
+	_, err = ParseCacheSizes(map[string]int{"databases": 1})
+	a.ErrorContains(err, `unknown cache "databases"`)
+}
@@ -42,7 +42,7 @@ type PipelineFind struct {
 
 // CreatePipelineAIO creates a pipeline with tasks all in one.
 func (s *Store) CreatePipelineAIO(ctx context.Context, planUID int64, pipeline *PipelineMessage, creatorUID int) (createdPipelineUID int, err error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to begin tx")
 	}
@@ -127,7 +127,7 @@ func (s *Store) CreatePipelineAIO(ctx context.Context, planUID int64, pipeline *
 }
 
 // returns func() to invalidate cache.
-func (*Store) updatePipelineUIDOfPlan(ctx context.Context, txn *sql.Tx, planUID int64, pipelineUID int) (func(), error) {
+func (*Store) updatePipelineUIDOfPlan(ctx context.Context, txn *trackedTx, planUID int64, pipelineUID int) (func(), error) {
 	q := qb.Q().Space(`
 		UPDATE plan
 		SET pipeline_id = ?
@@ -146,7 +146,7 @@ func (*Store) updatePipelineUIDOfPlan(ctx context.Context, txn *sql.Tx, planUID
 	}, nil
 }
 
-func lockPlanAndGetPipelineUID(ctx context.Context, txn *sql.Tx, planUID int64) (*int, error) {
+func lockPlanAndGetPipelineUID(ctx context.Context, txn *trackedTx, planUID int64) (*int, error) {
 	q := qb.Q().Space("SELECT pipeline_id FROM plan WHERE id = ? FOR UPDATE", planUID)
 	query, args, err := q.ToSQL()
 	if err != nil {
@@ -167,7 +167,7 @@ func lockPlanAndGetPipelineUID(ctx context.Context, txn *sql.Tx, planUID int64)
 	return nil, nil
 }
 
-func (*Store) createPipeline(ctx context.Context, txn *sql.Tx, create *PipelineMessage, creatorUID int) (*PipelineMessage, error) {
+func (*Store) createPipeline(ctx context.Context, txn *trackedTx, create *PipelineMessage, creatorUID int) (*PipelineMessage, error) {
 	q := qb.Q().Space(`
 		INSERT INTO pipeline (
 			project,
@@ -275,7 +275,7 @@ func (s *Store) ListPipelineV2(ctx context.Context, find *PipelineFind) ([]*Pipe
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +73,7 @@ func (s *Store) CreatePlanCheckRuns(ctx context.Context, plan *PlanMessage, crea
 		return nil
 	}
 
-	txn, err := s.GetDB().BeginTx(ctx, nil)
+	txn, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -153,7 +153,7 @@ WHERE TRUE`)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +211,7 @@ func (s *Store) UpdatePlanCheckRun(ctx context.Context, status PlanCheckRunStatu
 	if err != nil {
 		return errors.Wrapf(err, "failed to build sql")
 	}
-	if _, err := s.GetDB().ExecContext(ctx, query, args...); err != nil {
+	if _, err := s.execContext(ctx, query, args...); err != nil {
 		return errors.Wrapf(err, "failed to update plan check run")
 	}
 	return nil
@@ -229,7 +229,7 @@ func (s *Store) BatchCancelPlanCheckRuns(ctx context.Context, planCheckRunUIDs [
 	if err != nil {
 		return errors.Wrapf(err, "failed to build sql")
 	}
-	if _, err := s.GetDB().ExecContext(ctx, query, args...); err != nil {
+	if _, err := s.execContext(ctx, query, args...); err != nil {
 		return err
 	}
 	return nil
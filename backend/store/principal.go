@@ -2,7 +2,6 @@ package store
 
 import (
 	"context"
-	"database/sql"
 	"log/slog"
 	"strings"
 	"time"
@@ -128,7 +127,7 @@ func (s *Store) StatUsers(ctx context.Context) ([]*UserStat, error) {
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, sql, args...)
+	rows, err := s.readQueryContext(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +161,7 @@ func (s *Store) StatUsers(ctx context.Context) ([]*UserStat, error) {
 
 // ListUsers list users.
 func (s *Store) ListUsers(ctx context.Context, find *FindUserMessage) ([]*UserMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +185,7 @@ func (s *Store) ListUsers(ctx context.Context, find *FindUserMessage) ([]*UserMe
 
 // listAndCacheAllUsers is used for caching all users.
 func (s *Store) listAndCacheAllUsers(ctx context.Context) error {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -208,7 +207,7 @@ func (s *Store) listAndCacheAllUsers(ctx context.Context) error {
 	return nil
 }
 
-func listUserImpl(ctx context.Context, txn *sql.Tx, find *FindUserMessage) ([]*UserMessage, error) {
+func listUserImpl(ctx context.Context, txn *trackedTx, find *FindUserMessage) ([]*UserMessage, error) {
 	with := qb.Q()
 	from := qb.Q().Space("principal INNER JOIN user_groups ON principal.id = user_groups.user_id")
 	where := qb.Q().Space("TRUE")
@@ -357,7 +356,7 @@ func (s *Store) CreateUser(ctx context.Context, create *UserMessage) (*UserMessa
 		return nil, errors.Errorf("emails must be lower-case when they are passed into store")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -464,7 +463,7 @@ func (s *Store) UpdateUser(ctx context.Context, currentUser *UserMessage, patch
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +64,7 @@ func (s *Store) GetProjectV2(ctx context.Context, find *FindProjectMessage) (*Pr
 	// We will always return the resource regardless of its deleted state.
 	find.ShowDeleted = true
 
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +92,7 @@ func (s *Store) GetProjectV2(ctx context.Context, find *FindProjectMessage) (*Pr
 
 // ListProjectV2 lists all projects.
 func (s *Store) ListProjectV2(ctx context.Context, find *FindProjectMessage) ([]*ProjectMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +127,7 @@ func (s *Store) CreateProjectV2(ctx context.Context, create *ProjectMessage, cre
 		return nil, err
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -188,7 +188,7 @@ func (s *Store) CreateProjectV2(ctx context.Context, create *ProjectMessage, cre
 func (s *Store) UpdateProjectV2(ctx context.Context, patch *UpdateProjectMessage) (*ProjectMessage, error) {
 	s.removeProjectCache(patch.ResourceID)
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +216,7 @@ func (s *Store) BatchUpdateProjectsV2(ctx context.Context, patches []*UpdateProj
 		s.removeProjectCache(patch.ResourceID)
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -246,7 +246,7 @@ func (s *Store) BatchUpdateProjectsV2(ctx context.Context, patches []*UpdateProj
 	return updatedProjects, nil
 }
 
-func updateProjectImplV2(ctx context.Context, txn *sql.Tx, patch *UpdateProjectMessage) error {
+func updateProjectImplV2(ctx context.Context, txn *trackedTx, patch *UpdateProjectMessage) error {
 	set := qb.Q()
 
 	if v := patch.Title; v != nil {
@@ -283,7 +283,7 @@ func updateProjectImplV2(ctx context.Context, txn *sql.Tx, patch *UpdateProjectM
 	return nil
 }
 
-func (s *Store) listProjectImplV2(ctx context.Context, txn *sql.Tx, find *FindProjectMessage) ([]*ProjectMessage, error) {
+func (s *Store) listProjectImplV2(ctx context.Context, txn *trackedTx, find *FindProjectMessage) ([]*ProjectMessage, error) {
 	q := qb.Q().Space("SELECT resource_id, name, data_classification_config_id, setting, deleted FROM project WHERE TRUE")
 	if filterQ := find.FilterQ; filterQ != nil {
 		q.And("?", filterQ)
@@ -362,7 +362,9 @@ func (s *Store) removeProjectCache(resourceID string) {
 // - Test cleanup
 // Following AIP-164/165, this only works on projects where deleted = TRUE.
 func (s *Store) DeleteProject(ctx context.Context, resourceID string) error {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	ctx, cancel := s.withLongQueryTimeout(ctx)
+	defer cancel()
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
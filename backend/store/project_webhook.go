@@ -66,7 +66,7 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, projectID string, cr
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -89,7 +89,7 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, projectID string, cr
 
 // FindProjectWebhookV2 finds a list of ProjectWebhook instances.
 func (s *Store) FindProjectWebhookV2(ctx context.Context, find *FindProjectWebhookMessage) ([]*ProjectWebhookMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -107,7 +107,7 @@ func (s *Store) FindProjectWebhookV2(ctx context.Context, find *FindProjectWebho
 
 // GetProjectWebhookV2 gets an instance of ProjectWebhook.
 func (s *Store) GetProjectWebhookV2(ctx context.Context, find *FindProjectWebhookMessage) (*ProjectWebhookMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -133,7 +133,7 @@ func (s *Store) GetProjectWebhookV2(ctx context.Context, find *FindProjectWebhoo
 
 // UpdateProjectWebhookV2 updates an instance of ProjectWebhook.
 func (s *Store) UpdateProjectWebhookV2(ctx context.Context, projectResourceID string, projectWebhookID int, update *UpdateProjectWebhookMessage) (*ProjectWebhookMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -196,7 +196,7 @@ func (s *Store) DeleteProjectWebhookV2(ctx context.Context, projectResourceID st
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -213,7 +213,7 @@ func (s *Store) DeleteProjectWebhookV2(ctx context.Context, projectResourceID st
 	return nil
 }
 
-func (*Store) findProjectWebhookImplV2(ctx context.Context, txn *sql.Tx, find *FindProjectWebhookMessage) ([]*ProjectWebhookMessage, error) {
+func (*Store) findProjectWebhookImplV2(ctx context.Context, txn *trackedTx, find *FindProjectWebhookMessage) ([]*ProjectWebhookMessage, error) {
 	q := qb.Q().Space(`
 		SELECT
 			id,
@@ -93,7 +93,7 @@ func (s *Store) CreateQueryHistory(ctx context.Context, create *QueryHistoryMess
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -159,14 +159,9 @@ func (s *Store) ListQueryHistories(ctx context.Context, find *FindQueryHistoryMe
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-
 	var queryHistories []*QueryHistoryMessage
-	rows, err := tx.QueryContext(ctx, sql, args...)
+	// The SQL editor lists the history right after recording a query, so this must read from the primary.
+	rows, err := s.queryContext(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -200,10 +195,6 @@ func (s *Store) ListQueryHistories(ctx context.Context, find *FindQueryHistoryMe
 		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
-
 	return queryHistories, nil
 }
 
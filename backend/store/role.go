@@ -50,7 +50,7 @@ func (s *Store) GetResourcesUsedByRole(ctx context.Context, role string) ([]*Rol
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +95,7 @@ func (s *Store) CreateRole(ctx context.Context, create *RoleMessage) (*RoleMessa
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, query, args...); err != nil {
+	if _, err := s.execContext(ctx, query, args...); err != nil {
 		return nil, err
 	}
 	s.rolesCache.Add(create.ResourceID, create)
@@ -124,7 +124,7 @@ func (s *Store) GetRole(ctx context.Context, resourceID string) (*RoleMessage, e
 		Permissions: map[string]bool{},
 	}
 	var permissions []byte
-	if err := s.GetDB().QueryRowContext(ctx, query, args...).Scan(&role.Name, &role.Description, &permissions); err != nil {
+	if err := s.queryRowContext(ctx, query, args...).Scan(&role.Name, &role.Description, &permissions); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -155,7 +155,7 @@ func (s *Store) ListRoles(ctx context.Context) ([]*RoleMessage, error) {
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +229,7 @@ func (s *Store) UpdateRole(ctx context.Context, patch *UpdateRoleMessage) (*Role
 		Permissions: map[string]bool{},
 	}
 	var permissionBytes []byte
-	if err := s.GetDB().QueryRowContext(ctx, query, args...).Scan(&role.Name, &role.Description, &permissionBytes); err != nil {
+	if err := s.queryRowContext(ctx, query, args...).Scan(&role.Name, &role.Description, &permissionBytes); err != nil {
 		return nil, err
 	}
 	s.rolesCache.Remove(patch.ResourceID)
@@ -257,7 +257,7 @@ func (s *Store) DeleteRole(ctx context.Context, resourceID string) error {
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, query, args...); err != nil {
+	if _, err := s.execContext(ctx, query, args...); err != nil {
 		return err
 	}
 	s.rolesCache.Remove(resourceID)
@@ -202,7 +202,7 @@ func (s *Store) GetSettingV2(ctx context.Context, name storepb.SettingName) (*Se
 		return v, nil
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -229,7 +229,7 @@ func (s *Store) GetSettingV2(ctx context.Context, name storepb.SettingName) (*Se
 
 // ListSettingV2 returns a list of settings.
 func (s *Store) ListSettingV2(ctx context.Context, find *FindSettingMessage) ([]*SettingMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -277,7 +277,7 @@ func (s *Store) UpsertSettingV2(ctx context.Context, update *SetSettingMessage)
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -313,7 +313,7 @@ func (s *Store) CreateSettingIfNotExistV2(ctx context.Context, create *SettingMe
 		return v, false, nil
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, false, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -370,7 +370,7 @@ func (s *Store) DeleteSettingV2(ctx context.Context, name storepb.SettingName) e
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
@@ -388,7 +388,7 @@ func (s *Store) DeleteSettingV2(ctx context.Context, name storepb.SettingName) e
 	return nil
 }
 
-func listSettingV2Impl(ctx context.Context, txn *sql.Tx, find *FindSettingMessage) ([]*SettingMessage, error) {
+func listSettingV2Impl(ctx context.Context, txn *trackedTx, find *FindSettingMessage) ([]*SettingMessage, error) {
 	q := qb.Q().Space(`
 		SELECT
 			name,
@@ -146,7 +146,7 @@ func (s *Store) listSheets(ctx context.Context, find *FindSheetMessage) ([]*Shee
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +229,7 @@ func (s *Store) CreateSheet(ctx context.Context, create *SheetMessage) (*SheetMe
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -256,6 +256,8 @@ func (s *Store) CreateSheet(ctx context.Context, create *SheetMessage) (*SheetMe
 // You should not use this function directly to create sheets.
 // Use BatchCreateSheet in component/sheet instead.
 func (s *Store) BatchCreateSheet(ctx context.Context, projectID string, creates []*SheetMessage, creatorUID int) ([]*SheetMessage, error) {
+	ctx, cancel := s.withLongQueryTimeout(ctx)
+	defer cancel()
 	var names []string
 	var statements []string
 	var sha256s [][]byte
@@ -302,7 +304,7 @@ func (s *Store) BatchCreateSheet(ctx context.Context, projectID string, creates
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to begin tx")
 	}
@@ -339,6 +341,8 @@ func (s *Store) BatchCreateSheet(ctx context.Context, projectID string, creates
 }
 
 func (s *Store) BatchCreateSheetBlob(ctx context.Context, sha256s [][]byte, contents []string) error {
+	ctx, cancel := s.withLongQueryTimeout(ctx)
+	defer cancel()
 	q := qb.Q().Space(`
 		INSERT INTO sheet_blob (
 			sha256,
@@ -354,7 +358,7 @@ func (s *Store) BatchCreateSheetBlob(ctx context.Context, sha256s [][]byte, cont
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, query, args...); err != nil {
+	if _, err := s.execContext(ctx, query, args...); err != nil {
 		return errors.Wrapf(err, "failed to exec")
 	}
 
@@ -387,7 +391,7 @@ func (s *Store) PatchSheet(ctx context.Context, patch *PatchSheetMessage) (*Shee
 	}
 
 	var uid int
-	if err := s.GetDB().QueryRowContext(ctx, query, args...).Scan(
+	if err := s.queryRowContext(ctx, query, args...).Scan(
 		&uid,
 	); err != nil {
 		if err == sql.ErrNoRows {
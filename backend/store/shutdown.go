@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultDrainTimeout is how long Close waits for in-flight operations before closing the connections.
+const defaultDrainTimeout = 10 * time.Second
+
+// ErrShuttingDown is returned by store operations started after Close.
+var ErrShuttingDown = errors.New("store is shutting down")
+
+// RegisterStopper registers a function that stops a background worker using the store.
+// Close calls the stoppers in reverse registration order before it stops accepting operations,
+// so a worker can finish what it is doing. A stopper must return once the worker has stopped.
+func (s *Store) RegisterStopper(stop func()) {
+	s.shutdownMu.Lock()
+	defer s.shutdownMu.Unlock()
+	s.stoppers = append(s.stoppers, stop)
+}
+
+// Close shuts the store down gracefully.
+// It runs the registered stoppers, rejects new operations with ErrShuttingDown,
+// waits up to the drain timeout for in-flight operations, and then closes the database connections.
+//
+// Operations are tracked by the accessors. Transactions are tracked until they are committed or rolled back,
+// and rows until they are closed or read to the end.
+// Only the first call closes the store; later calls return right away.
+func (s *Store) Close() error {
+	s.shutdownMu.Lock()
+	if s.closing {
+		s.shutdownMu.Unlock()
+		return nil
+	}
+	// Operations are still accepted while the stoppers run.
+	s.closing = true
+	stoppers := s.stoppers
+	s.shutdownMu.Unlock()
+
+	for i := len(stoppers) - 1; i >= 0; i-- {
+		stoppers[i]()
+	}
+
+	s.shutdownMu.Lock()
+	s.shuttingDown = true
+	s.shutdownMu.Unlock()
+
+	if s.drainTimeout > 0 {
+		drained := make(chan struct{})
+		go func() {
+			s.inflight.Wait()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+		case <-time.After(s.drainTimeout):
+			slog.Warn("Timed out waiting for in-flight store operations, closing the database connections", "timeout", s.drainTimeout)
+		}
+	}
+
+	return s.dbConnManager.Close()
+}
+
+// startOperation registers an in-flight operation, which must be ended by calling s.inflight.Done.
+// It fails once the store is shutting down.
+func (s *Store) startOperation() error {
+	s.shutdownMu.RLock()
+	defer s.shutdownMu.RUnlock()
+	if s.shuttingDown {
+		return ErrShuttingDown
+	}
+	s.inflight.Add(1)
+	return nil
+}
+
+// releaseOperation returns a function that cancels the context of an operation started by startOperation and ends it.
+// The function may be called more than once.
+func (s *Store) releaseOperation(cancel context.CancelFunc) func() {
+	return sync.OnceFunc(func() {
+		cancel()
+		s.inflight.Done()
+	})
+}
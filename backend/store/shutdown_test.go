@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseDrainsInFlightOperations(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	s := newTestStore(db)
+	s.drainTimeout = 5 * time.Second
+
+	started := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- s.RunInTx(ctx, func(tx *trackedTx) error {
+			close(started)
+			// The transaction keeps running while Close is waiting.
+			time.Sleep(100 * time.Millisecond)
+			_, err := tx.ExecContext(ctx, "UPDATE t SET a = 1")
+			return err
+		})
+	}()
+	<-started
+
+	a.NoError(s.Close())
+	a.NoError(<-done)
+	a.Equal([]string{"BEGIN", "UPDATE t SET a = 1", "COMMIT", "CLOSE"}, fake.recorded())
+
+	// New operations are rejected once the store is closing.
+	_, err := s.execContext(ctx, "UPDATE t SET a = 2")
+	a.ErrorIs(err, ErrShuttingDown)
+	_, err = s.queryContext(ctx, "SELECT 1")
+	a.ErrorIs(err, ErrShuttingDown)
+	var v int
+	a.ErrorIs(s.queryRowContext(ctx, "SELECT 1").Scan(&v), ErrShuttingDown)
+	a.ErrorIs(s.RunInTx(ctx, func(*trackedTx) error { return nil }), ErrShuttingDown)
+	a.NoError(s.Close())
+}
+
+func TestCloseDrainTimeout(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	s := newTestStore(db)
+	s.drainTimeout = 20 * time.Millisecond
+
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	go func() {
+		_ = s.RunInTx(ctx, func(*trackedTx) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	// Close gives up on an operation that outlives the drain timeout.
+	begin := time.Now()
+	a.NoError(s.Close())
+	a.Less(time.Since(begin), time.Second)
+	a.Equal([]string{"BEGIN", "CLOSE"}, fake.recorded())
+}
+
+func TestCloseRunsStoppersFirst(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	s := newTestStore(db)
+
+	var stopped []string
+	s.RegisterStopper(func() {
+		stopped = append(stopped, "first")
+	})
+	s.RegisterStopper(func() {
+		// A stopping worker can still use the store to finish its work.
+		_, err := s.execContext(ctx, "UPDATE t SET a = 1")
+		a.NoError(err)
+		stopped = append(stopped, "second")
+	})
+
+	a.NoError(s.Close())
+	a.Equal([]string{"second", "first"}, stopped)
+	a.Equal([]string{"UPDATE t SET a = 1", "CLOSE"}, fake.recorded())
+}
+
+func TestCloseConcurrently(t *testing.T) {
+	a := require.New(t)
+	_, db := newFakeDB(t)
+	s := newTestStore(db)
+
+	var calls atomic.Int32
+	stopping := make(chan struct{})
+	release := make(chan struct{})
+	s.RegisterStopper(func() {
+		calls.Add(1)
+		close(stopping)
+		<-release
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- s.Close()
+	}()
+	<-stopping
+
+	// A second Close while the stoppers run does not run them again.
+	a.NoError(s.Close())
+	close(release)
+	a.NoError(<-done)
+	a.Equal(int32(1), calls.Load())
+}
+
+func TestCloseWaitsForOpenTransaction(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	s := newTestStore(db)
+	s.drainTimeout = 5 * time.Second
+
+	tx, err := s.beginTx(ctx, nil)
+	a.NoError(err)
+	closed := make(chan error)
+	go func() {
+		closed <- s.Close()
+	}()
+
+	// Close must not close the connections under the open transaction.
+	time.Sleep(50 * time.Millisecond)
+	_, err = tx.ExecContext(ctx, "UPDATE t SET a = 1")
+	a.NoError(err)
+	select {
+	case <-closed:
+		a.Fail("Close returned before the transaction ended")
+	default:
+	}
+	a.NoError(tx.Commit())
+	a.NoError(<-closed)
+	a.Equal([]string{"BEGIN", "UPDATE t SET a = 1", "COMMIT", "CLOSE"}, fake.recorded())
+}
+
+func TestCloseWaitsForOpenRows(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	fake, db := newFakeDB(t)
+	s := newTestStore(db)
+	s.drainTimeout = 5 * time.Second
+
+	rows, err := s.queryContext(ctx, "SELECT 1")
+	a.NoError(err)
+	closed := make(chan error)
+	go func() {
+		closed <- s.Close()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-closed:
+		a.Fail("Close returned before the rows were read")
+	default:
+	}
+	// Reading the last row ends the operation.
+	a.True(rows.Next())
+	a.False(rows.Next())
+	a.NoError(rows.Err())
+	a.NoError(<-closed)
+	// Closing the rows again is a no-op.
+	a.NoError(rows.Close())
+	a.Equal([]string{"SELECT 1", "CLOSE"}, fake.recorded())
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+)
+
+const (
+	// defaultSlowQueryThreshold is the duration above which a metadata DB query is logged as slow.
+	defaultSlowQueryThreshold = 500 * time.Millisecond
+	// slowQueryMaxSQLLength and slowQueryMaxArgs bound the size of a slow query log entry.
+	slowQueryMaxSQLLength = 512
+	slowQueryMaxArgs      = 10
+)
+
+// logSlowQuery logs the query if it took longer than the slow query threshold.
+func (t *metadataDBTracer) logSlowQuery(data queryTracerData, duration time.Duration, rows int64, err error) {
+	if t.slowQueryThreshold <= 0 || duration < t.slowQueryThreshold {
+		return
+	}
+	name := data.method
+	if name == "" {
+		name = extractQueryOperation(data.sql)
+	}
+	attrs := []any{
+		slog.String("name", name),
+		slog.Duration("duration", duration),
+		slog.Int64("rows", rows),
+		slog.String("sql", truncateSQL(data.sql)),
+		slog.String("args", summarizeQueryArgs(data.args)),
+	}
+	if err != nil {
+		attrs = append(attrs, log.BBError(err))
+	}
+	slog.Warn("slow metadata DB query", attrs...)
+}
+
+func truncateSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > slowQueryMaxSQLLength {
+		return sql[:slowQueryMaxSQLLength] + "..."
+	}
+	return sql
+}
+
+// summarizeQueryArgs describes query arguments without leaking their content.
+// Arguments are positional, so there is no column name to tell a comment or a payload holding old and new values
+// from an identifier. Only numbers, booleans and timestamps are printed; text and binary values are reduced to their length.
+func summarizeQueryArgs(args []any) string {
+	var parts []string
+	for i, arg := range args {
+		if i == slowQueryMaxArgs {
+			parts = append(parts, fmt.Sprintf("...(%d more)", len(args)-slowQueryMaxArgs))
+			break
+		}
+		parts = append(parts, summarizeQueryArg(arg))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func summarizeQueryArg(arg any) string {
+	switch v := arg.(type) {
+	case nil:
+		return "NULL"
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case string:
+		return fmt.Sprintf("string(len=%d)", len(v))
+	case []byte:
+		return fmt.Sprintf("bytes(len=%d)", len(v))
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
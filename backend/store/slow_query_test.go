@@ -0,0 +1,78 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLogs redirects the default logger to a buffer for the duration of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+// slowTestQuery mimics a store method issuing a slow query through pgx.
+func (*Store) slowTestQuery(tracer *metadataDBTracer, delay time.Duration) {
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL:  "UPDATE issue_comment SET payload = $1 WHERE id = $2",
+		Args: []any{`{"comment": "my password is hunter2"}`, 101},
+	})
+	time.Sleep(delay)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	a := require.New(t)
+	buf := captureLogs(t)
+
+	(&Store{}).slowTestQuery(&metadataDBTracer{methodMetrics: true, slowQueryThreshold: 10 * time.Millisecond}, 20*time.Millisecond)
+
+	var entry map[string]any
+	a.NoError(json.Unmarshal(buf.Bytes(), &entry))
+	a.Equal("WARN", entry["level"])
+	a.Equal("slow metadata DB query", entry["msg"])
+	a.Equal("slowTestQuery", entry["name"])
+	a.EqualValues(1, entry["rows"])
+	a.GreaterOrEqual(entry["duration"], float64(20*time.Millisecond))
+	a.Equal("UPDATE issue_comment SET payload = $1 WHERE id = $2", entry["sql"])
+	a.Equal("[string(len=37), 101]", entry["args"])
+	a.NotContains(buf.String(), "hunter2")
+}
+
+func TestSlowQueryLogBelowThreshold(t *testing.T) {
+	buf := captureLogs(t)
+	(&Store{}).slowTestQuery(&metadataDBTracer{methodMetrics: true, slowQueryThreshold: time.Minute}, 0)
+	require.Empty(t, buf.String())
+
+	// A zero threshold disables the log.
+	(&Store{}).slowTestQuery(&metadataDBTracer{methodMetrics: true}, time.Millisecond)
+	require.Empty(t, buf.String())
+}
+
+func TestSummarizeQueryArgs(t *testing.T) {
+	a := require.New(t)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a.Equal("[NULL, true, 42, 2024-01-02T03:04:05Z, string(len=5), bytes(len=3), []string]",
+		summarizeQueryArgs([]any{nil, true, int64(42), ts, "hello", []byte("abc"), []string{"a"}}))
+
+	var args []any
+	for i := range 12 {
+		args = append(args, i)
+	}
+	a.Equal("[0, 1, 2, 3, 4, 5, 6, 7, 8, 9, ...(2 more)]", summarizeQueryArgs(args))
+	a.Equal(strings.Repeat("a", slowQueryMaxSQLLength)+"...", truncateSQL(strings.Repeat("a", slowQueryMaxSQLLength+1)))
+	a.Equal("SELECT 1 FROM t", truncateSQL("SELECT 1\n\t\tFROM t"))
+}
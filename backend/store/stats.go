@@ -19,7 +19,7 @@ type CountInstanceMessage struct {
 
 // CountUsers counts the principal.
 func (s *Store) CountUsers(ctx context.Context, userType storepb.PrincipalType) (int, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -56,7 +56,7 @@ func (s *Store) CountInstance(ctx context.Context, find *CountInstanceMessage) (
 		return 0, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -74,7 +74,7 @@ func (s *Store) CountInstance(ctx context.Context, find *CountInstanceMessage) (
 
 // CountActiveUsers counts the number of endusers.
 func (s *Store) CountActiveUsers(ctx context.Context) (int, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -102,7 +102,7 @@ func (s *Store) CountActiveUsers(ctx context.Context) (int, error) {
 // CountProjects counts the number of projects and group by workflow type.
 // Used by the metric collector.
 func (s *Store) CountProjects(ctx context.Context) (int, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -127,7 +127,7 @@ func (s *Store) CountProjects(ctx context.Context) (int, error) {
 // CountIssues counts the number of issues.
 // Used by the metric collector.
 func (s *Store) CountIssues(ctx context.Context) (int, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -152,7 +152,7 @@ func (s *Store) CountIssues(ctx context.Context) (int, error) {
 // CountInstanceGroupByEngineAndEnvironmentID counts the number of instances and group by engine and environment.
 // Used by the metric collector.
 func (s *Store) CountInstanceGroupByEngineAndEnvironmentID(ctx context.Context) ([]*metric.InstanceCountMetric, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
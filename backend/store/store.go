@@ -5,8 +5,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/pkg/errors"
 
 	storepb "github.com/bytebase/bytebase/backend/generated-go/store"
 	"github.com/bytebase/bytebase/backend/store/model"
@@ -16,6 +20,18 @@ import (
 type Store struct {
 	dbConnManager *DBConnectionManager
 	enableCache   bool
+	// queryTimeout bounds queries whose context has no deadline. Zero disables it.
+	queryTimeout time.Duration
+	// healthCheckFailures is the number of consecutive failed health checks.
+	healthCheckFailures atomic.Int64
+
+	// Graceful shutdown, see Close.
+	drainTimeout time.Duration
+	shutdownMu   sync.RWMutex
+	closing      bool
+	shuttingDown bool
+	stoppers     []func()
+	inflight     sync.WaitGroup
 
 	// Cache.
 	Secret               string
@@ -42,114 +58,92 @@ type Store struct {
 
 // New creates a new instance of Store.
 // pgURL can be either a direct PostgreSQL URL or a file path containing the URL.
-func New(ctx context.Context, pgURL string, enableCache bool) (*Store, error) {
-	userIDCache, err := lru.New[int, *UserMessage](32768)
-	if err != nil {
-		return nil, err
+func New(ctx context.Context, pgURL string, opts ...Option) (*Store, error) {
+	o := newOptions(opts...)
+	s := &Store{
+		enableCache:  o.enableCache,
+		queryTimeout: o.queryTimeout,
+		drainTimeout: o.drainTimeout,
 	}
-	userEmailCache, err := lru.New[string, *UserMessage](32768)
-	if err != nil {
+	if err := s.initCaches(o.cacheSizes); err != nil {
 		return nil, err
 	}
-	instanceCache, err := lru.New[string, *InstanceMessage](32768)
-	if err != nil {
+
+	// Initialize database connection (handles both direct URL and file-based)
+	dbConnManager := NewDBConnectionManager(pgURL, o.pgReplicaURL, o.methodMetrics, o.slowQueryThreshold)
+	if err := dbConnManager.Initialize(ctx); err != nil {
 		return nil, err
 	}
-	databaseCache, err := lru.New[string, *DatabaseMessage](32768)
-	if err != nil {
-		return nil, err
+	s.dbConnManager = dbConnManager
+
+	return s, nil
+}
+
+func (s *Store) initCaches(sizes CacheSizes) error {
+	var err error
+	if s.userIDCache, err = newCache[int, *UserMessage]("user", sizes.User); err != nil {
+		return err
 	}
-	projectCache, err := lru.New[string, *ProjectMessage](32768)
-	if err != nil {
-		return nil, err
+	if s.userEmailCache, err = newCache[string, *UserMessage]("user", sizes.User); err != nil {
+		return err
 	}
-	policyCache, err := lru.New[string, *PolicyMessage](128)
-	if err != nil {
-		return nil, err
+	if s.instanceCache, err = newCache[string, *InstanceMessage]("instance", sizes.Instance); err != nil {
+		return err
 	}
-	issueCache, err := lru.New[int, *IssueMessage](256)
-	if err != nil {
-		return nil, err
+	if s.databaseCache, err = newCache[string, *DatabaseMessage]("database", sizes.Database); err != nil {
+		return err
 	}
-	issueByPipelineCache, err := lru.New[int, *IssueMessage](256)
-	if err != nil {
-		return nil, err
+	if s.projectCache, err = newCache[string, *ProjectMessage]("project", sizes.Project); err != nil {
+		return err
 	}
-	pipelineCache, err := lru.New[int, *PipelineMessage](256)
-	if err != nil {
-		return nil, err
+	if s.policyCache, err = newCache[string, *PolicyMessage]("policy", sizes.Policy); err != nil {
+		return err
 	}
-	settingCache, err := lru.New[storepb.SettingName, *SettingMessage](64)
-	if err != nil {
-		return nil, err
+	if s.issueCache, err = newCache[int, *IssueMessage]("issue", sizes.Issue); err != nil {
+		return err
 	}
-	idpCache, err := lru.New[string, *IdentityProviderMessage](4)
-	if err != nil {
-		return nil, err
+	if s.issueByPipelineCache, err = newCache[int, *IssueMessage]("issue", sizes.Issue); err != nil {
+		return err
 	}
-	databaseGroupCache, err := lru.New[string, *DatabaseGroupMessage](1024)
-	if err != nil {
-		return nil, err
+	if s.pipelineCache, err = newCache[int, *PipelineMessage]("pipeline", sizes.Pipeline); err != nil {
+		return err
 	}
-	rolesCache, err := lru.New[string, *RoleMessage](64)
-	if err != nil {
-		return nil, err
+	if s.settingCache, err = newCache[storepb.SettingName, *SettingMessage]("setting", sizes.Setting); err != nil {
+		return err
 	}
-	sheetCache, err := lru.New[int, *SheetMessage](64)
-	if err != nil {
-		return nil, err
+	if s.idpCache, err = newCache[string, *IdentityProviderMessage]("identity provider", sizes.IdentityProvider); err != nil {
+		return err
 	}
-	sheetStatementCache, err := lru.New[int, string](10)
-	if err != nil {
-		return nil, err
+	if s.databaseGroupCache, err = newCache[string, *DatabaseGroupMessage]("database group", sizes.DatabaseGroup); err != nil {
+		return err
 	}
-	dbMetadataCache, err := lru.New[string, *model.DatabaseMetadata](128)
-	if err != nil {
-		return nil, err
+	if s.rolesCache, err = newCache[string, *RoleMessage]("role", sizes.Role); err != nil {
+		return err
 	}
-	groupCache, err := lru.New[string, *GroupMessage](1024)
-	if err != nil {
-		return nil, err
+	if s.groupCache, err = newCache[string, *GroupMessage]("group", sizes.Group); err != nil {
+		return err
 	}
-
-	// Initialize database connection (handles both direct URL and file-based)
-	dbConnManager := NewDBConnectionManager(pgURL)
-	if err := dbConnManager.Initialize(ctx); err != nil {
-		return nil, err
+	if s.sheetCache, err = newCache[int, *SheetMessage]("sheet", sizes.Sheet); err != nil {
+		return err
 	}
-
-	s := &Store{
-		dbConnManager: dbConnManager,
-		enableCache:   enableCache,
-
-		// Cache.
-		userIDCache:          userIDCache,
-		userEmailCache:       userEmailCache,
-		instanceCache:        instanceCache,
-		databaseCache:        databaseCache,
-		projectCache:         projectCache,
-		policyCache:          policyCache,
-		issueCache:           issueCache,
-		issueByPipelineCache: issueByPipelineCache,
-		pipelineCache:        pipelineCache,
-		settingCache:         settingCache,
-		idpCache:             idpCache,
-		databaseGroupCache:   databaseGroupCache,
-		rolesCache:           rolesCache,
-		sheetCache:           sheetCache,
-		sheetStatementCache:  sheetStatementCache,
-		dbMetadataCache:      dbMetadataCache,
-		groupCache:           groupCache,
+	if s.sheetStatementCache, err = newCache[int, string]("sheet statement", sizes.SheetStatement); err != nil {
+		return err
 	}
-
-	return s, nil
+	if s.dbMetadataCache, err = newCache[string, *model.DatabaseMetadata]("database metadata", sizes.DBMetadata); err != nil {
+		return err
+	}
+	return nil
 }
 
-// Close closes underlying db.
-func (s *Store) Close() error {
-	return s.dbConnManager.Close()
+func newCache[K comparable, V any](name string, size int) (*lru.Cache[K, V], error) {
+	c, err := lru.New[K, V](size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s cache size %d", name, size)
+	}
+	return c, nil
 }
 
+// GetDB returns the database connection for callers outside the store, such as the migrator.
 func (s *Store) GetDB() *sql.DB {
 	return s.dbConnManager.GetDB()
 }
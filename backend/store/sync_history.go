@@ -45,7 +45,7 @@ func (s *Store) GetSyncHistoryByUID(ctx context.Context, uid int64) (*SyncHistor
 	}
 
 	var m []byte
-	if err := s.GetDB().QueryRowContext(ctx, query, args...).Scan(
+	if err := s.queryRowContext(ctx, query, args...).Scan(
 		&h.UID,
 		&h.CreatedAt,
 		&h.InstanceID,
@@ -86,7 +86,7 @@ func (s *Store) CreateSyncHistory(ctx context.Context, instanceID, databaseName
 		return 0, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to begin tx")
 	}
@@ -139,7 +139,7 @@ func (s *Store) FindBlockingTaskByVersion(ctx context.Context, pipelineUID int,
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +164,7 @@ func (s *Store) FindBlockingTaskByVersion(ctx context.Context, pipelineUID int,
 	return nil, nil
 }
 
-func (*Store) createTasks(ctx context.Context, txn *sql.Tx, creates ...*TaskMessage) ([]*TaskMessage, error) {
+func (*Store) createTasks(ctx context.Context, txn *trackedTx, creates ...*TaskMessage) ([]*TaskMessage, error) {
 	var (
 		pipelineIDs  []int
 		instances    []string
@@ -257,7 +257,7 @@ func (*Store) createTasks(ctx context.Context, txn *sql.Tx, creates ...*TaskMess
 	return tasks, nil
 }
 
-func (*Store) listTasksTx(ctx context.Context, txn *sql.Tx, find *TaskFind) ([]*TaskMessage, error) {
+func (*Store) listTasksTx(ctx context.Context, txn *trackedTx, find *TaskFind) ([]*TaskMessage, error) {
 	q := qb.Q().Space(`
 		SELECT
 			task.id,
@@ -371,7 +371,7 @@ func (*Store) listTasksTx(ctx context.Context, txn *sql.Tx, find *TaskFind) ([]*
 
 // ListTasks retrieves a list of tasks based on find.
 func (s *Store) ListTasks(ctx context.Context, find *TaskFind) ([]*TaskMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -438,7 +438,7 @@ func (s *Store) UpdateTaskV2(ctx context.Context, patch *TaskPatch) (*TaskMessag
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -490,7 +490,7 @@ func (s *Store) BatchSkipTasks(ctx context.Context, taskUIDs []int, comment stri
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, query, args...); err != nil {
+	if _, err := s.execContext(ctx, query, args...); err != nil {
 		return errors.Wrapf(err, "failed to batch skip tasks")
 	}
 
@@ -524,7 +524,7 @@ func (s *Store) ListTasksToAutoRollout(ctx context.Context, environments []strin
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
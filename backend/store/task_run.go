@@ -112,7 +112,7 @@ func (s *Store) ListTaskRunsV2(ctx context.Context, find *FindTaskRunMessage) ([
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +198,7 @@ func (s *Store) GetTaskRunByUID(ctx context.Context, uid int) (*TaskRunMessage,
 
 // UpdateTaskRunStatus updates task run status.
 func (s *Store) UpdateTaskRunStatus(ctx context.Context, patch *TaskRunStatusPatch) (*TaskRunMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to begin tx")
 	}
@@ -246,7 +246,7 @@ func (s *Store) UpdateTaskRunStartAt(ctx context.Context, taskRunID int) error {
 	}
 
 	var pipelineID int
-	if err := s.GetDB().QueryRowContext(ctx, query, args...).Scan(&pipelineID); err != nil {
+	if err := s.queryRowContext(ctx, query, args...).Scan(&pipelineID); err != nil {
 		return errors.Wrapf(err, "failed to update task run start at")
 	}
 
@@ -316,7 +316,7 @@ func (s *Store) CreatePendingTaskRuns(ctx context.Context, creatorID int, create
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, query, args...); err != nil {
+	if _, err := s.execContext(ctx, query, args...); err != nil {
 		return errors.Wrapf(err, "failed to create pending task runs")
 	}
 
@@ -324,7 +324,7 @@ func (s *Store) CreatePendingTaskRuns(ctx context.Context, creatorID int, create
 }
 
 // patchTaskRunStatusImpl updates a taskRun status. Returns the new state of the taskRun after update.
-func (*Store) patchTaskRunStatusImpl(ctx context.Context, txn *sql.Tx, patch *TaskRunStatusPatch) (*TaskRunMessage, error) {
+func (*Store) patchTaskRunStatusImpl(ctx context.Context, txn *trackedTx, patch *TaskRunStatusPatch) (*TaskRunMessage, error) {
 	set := qb.Q()
 
 	set.Comma("updated_at = ?, status = ?", time.Now(), patch.Status.String())
@@ -393,7 +393,7 @@ func (s *Store) BatchCancelTaskRuns(ctx context.Context, taskRunIDs []int) error
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, query, args...)
+	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get pipeline IDs")
 	}
@@ -422,7 +422,7 @@ func (s *Store) BatchCancelTaskRuns(ctx context.Context, taskRunIDs []int) error
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, query2, args2...); err != nil {
+	if _, err := s.execContext(ctx, query2, args2...); err != nil {
 		return err
 	}
 
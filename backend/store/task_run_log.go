@@ -49,7 +49,7 @@ func (s *Store) CreateTaskRunLog(ctx context.Context, taskRunUID int, t time.Tim
 		return errors.Wrapf(err, "failed to build sql")
 	}
 
-	if _, err := s.GetDB().ExecContext(ctx, sql, args...); err != nil {
+	if _, err := s.execContext(ctx, sql, args...); err != nil {
 		return errors.Wrapf(err, "failed to create task run log")
 	}
 	return nil
@@ -70,7 +70,8 @@ func (s *Store) ListTaskRunLogs(ctx context.Context, taskRunUID int) ([]*TaskRun
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	rows, err := s.GetDB().QueryContext(ctx, sql, args...)
+	// The logs are only shown to users, so a few seconds of replication lag is fine.
+	rows, err := s.readQueryContext(ctx, sql, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to query task run log")
 	}
@@ -129,7 +129,7 @@ func (s *Store) ListWorkSheets(ctx context.Context, find *FindWorkSheetMessage,
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	tx, err := s.beginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +208,7 @@ func (s *Store) CreateWorkSheet(ctx context.Context, create *WorkSheetMessage) (
 		return nil, errors.Wrapf(err, "failed to build sql")
 	}
 
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -233,7 +233,7 @@ func (s *Store) CreateWorkSheet(ctx context.Context, create *WorkSheetMessage) (
 
 // PatchWorkSheet updates a sheet.
 func (s *Store) PatchWorkSheet(ctx context.Context, patch *PatchWorkSheetMessage) error {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrapf(err, "failed to begin transaction")
 	}
@@ -250,7 +250,7 @@ func (s *Store) PatchWorkSheet(ctx context.Context, patch *PatchWorkSheetMessage
 
 // DeleteWorkSheet deletes an existing sheet by ID.
 func (s *Store) DeleteWorkSheet(ctx context.Context, sheetUID int) error {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -278,7 +278,7 @@ func (s *Store) DeleteWorkSheet(ctx context.Context, sheetUID int) error {
 }
 
 // patchWorkSheetImpl updates a sheet's name/statement/visibility/instance/db_name/project.
-func patchWorkSheetImpl(ctx context.Context, txn *sql.Tx, patch *PatchWorkSheetMessage) error {
+func patchWorkSheetImpl(ctx context.Context, txn *trackedTx, patch *PatchWorkSheetMessage) error {
 	set := qb.Q()
 	set.Comma("updated_at = ?", time.Now())
 	if v := patch.Title; v != nil {
@@ -337,7 +337,7 @@ func (s *Store) GetWorksheetOrganizer(ctx context.Context, worksheetUID, princip
 		Payload:      &storepb.WorkSheetOrganizerPayload{},
 	}
 	var payload []byte
-	if err := s.GetDB().QueryRowContext(ctx, query, args...).Scan(
+	if err := s.queryRowContext(ctx, query, args...).Scan(
 		&worksheetOrganizer.UID,
 		&payload,
 	); err != nil {
@@ -357,7 +357,7 @@ func (s *Store) GetWorksheetOrganizer(ctx context.Context, worksheetUID, princip
 
 // UpsertWorksheetOrganizer upserts a new SheetOrganizerMessage.
 func (s *Store) UpsertWorksheetOrganizer(ctx context.Context, patch *WorksheetOrganizerMessage) (*WorksheetOrganizerMessage, error) {
-	tx, err := s.GetDB().BeginTx(ctx, nil)
+	tx, err := s.beginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
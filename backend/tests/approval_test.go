@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -434,3 +435,163 @@ func TestApprovalRuleNoMatch(t *testing.T) {
 	a.True(issue.ApprovalStatus == v1pb.Issue_APPROVED || issue.ApprovalStatus == v1pb.Issue_SKIPPED,
 		"Issue should be auto-approved when no rule matches, got status: %v", issue.ApprovalStatus)
 }
+
+// TestApproveIssueTwice tests that sending the same approval twice, as on a double click,
+// approves the step once and does not approve the next step on the user's behalf.
+func TestApproveIssueTwice(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+
+	ctx, err := ctl.StartServerWithExternalPg(ctx)
+	a.NoError(err)
+	defer ctl.Close(ctx)
+
+	// Create instance in prod environment
+	instanceDir := t.TempDir()
+	instanceResp, err := ctl.instanceServiceClient.CreateInstance(ctx, connect.NewRequest(&v1pb.CreateInstanceRequest{
+		InstanceId: generateRandomString("inst"),
+		Instance: &v1pb.Instance{
+			Title:       "Prod Instance",
+			Engine:      v1pb.Engine_SQLITE,
+			Environment: stringPtr("environments/prod"),
+			Activation:  true,
+			DataSources: []*v1pb.DataSource{{
+				Type: v1pb.DataSourceType_ADMIN,
+				Host: instanceDir,
+				Id:   "admin",
+			}},
+		},
+	}))
+	a.NoError(err)
+
+	// Create database
+	dbName := generateRandomString("db")
+	err = ctl.createDatabaseV2(ctx, ctl.project, instanceResp.Msg, nil, dbName, "")
+	a.NoError(err)
+
+	// The user is a workspace admin, so it can approve the first step but not the second.
+	_, err = ctl.settingServiceClient.UpdateSetting(ctx, connect.NewRequest(&v1pb.UpdateSettingRequest{
+		AllowMissing: true,
+		Setting: &v1pb.Setting{
+			Name: "settings/WORKSPACE_APPROVAL",
+			Value: &v1pb.Value{
+				Value: &v1pb.Value_WorkspaceApprovalSettingValue{
+					WorkspaceApprovalSettingValue: &v1pb.WorkspaceApprovalSetting{
+						Rules: []*v1pb.WorkspaceApprovalSetting_Rule{
+							{
+								Source: v1pb.WorkspaceApprovalSetting_Rule_CHANGE_DATABASE,
+								Condition: &expr.Expr{
+									Expression: `resource.environment_id == "prod"`,
+								},
+								Template: &v1pb.ApprovalTemplate{
+									Title: "Two Step Approval",
+									Flow: &v1pb.ApprovalFlow{
+										Roles: []string{"roles/workspaceAdmin", "roles/workspaceOwner"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}))
+	a.NoError(err)
+
+	// Create sheet with DDL statement
+	sheet, err := ctl.sheetServiceClient.CreateSheet(ctx, connect.NewRequest(&v1pb.CreateSheetRequest{
+		Parent: ctl.project.Name,
+		Sheet: &v1pb.Sheet{
+			Title:   "Test DDL Sheet",
+			Content: []byte("CREATE TABLE approve_twice_test (id INTEGER PRIMARY KEY);"),
+		},
+	}))
+	a.NoError(err)
+
+	// Create plan
+	planResp, err := ctl.planServiceClient.CreatePlan(ctx, connect.NewRequest(&v1pb.CreatePlanRequest{
+		Parent: ctl.project.Name,
+		Plan: &v1pb.Plan{
+			Title: "Test Approve Twice Plan",
+			Specs: []*v1pb.Plan_Spec{{
+				Id: uuid.NewString(),
+				Config: &v1pb.Plan_Spec_ChangeDatabaseConfig{
+					ChangeDatabaseConfig: &v1pb.Plan_ChangeDatabaseConfig{
+						Targets: []string{fmt.Sprintf("%s/databases/%s", instanceResp.Msg.Name, dbName)},
+						Sheet:   sheet.Msg.Name,
+						Type:    v1pb.DatabaseChangeType_MIGRATE,
+					},
+				},
+			}},
+		},
+	}))
+	a.NoError(err)
+
+	// Create issue
+	issueResp, err := ctl.issueServiceClient.CreateIssue(ctx, connect.NewRequest(&v1pb.CreateIssueRequest{
+		Parent: ctl.project.Name,
+		Issue: &v1pb.Issue{
+			Title:       "Test Issue Approve Twice",
+			Type:        v1pb.Issue_DATABASE_CHANGE,
+			Description: "Testing duplicate approvals",
+			Plan:        planResp.Msg.Name,
+		},
+	}))
+	a.NoError(err)
+
+	// Wait for approval finding to complete
+	var issue *v1pb.Issue
+	for i := 0; i < 5; i++ {
+		if i > 0 {
+			time.Sleep(3 * time.Second)
+		}
+
+		issueGetResp, err := ctl.issueServiceClient.GetIssue(ctx, connect.NewRequest(&v1pb.GetIssueRequest{
+			Name: issueResp.Msg.Name,
+		}))
+		a.NoError(err)
+		issue = issueGetResp.Msg
+
+		if issue.ApprovalStatus != v1pb.Issue_CHECKING {
+			break
+		}
+	}
+	a.Equal(v1pb.Issue_PENDING, issue.ApprovalStatus)
+
+	// Send the same approval twice at once.
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = ctl.issueServiceClient.ApproveIssue(ctx, connect.NewRequest(&v1pb.ApproveIssueRequest{
+				Name: issueResp.Msg.Name,
+			}))
+		}()
+	}
+	wg.Wait()
+
+	// A request that lost the race either returns the issue or is refused the second step.
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		a.Equal(connect.CodePermissionDenied, connect.CodeOf(err), err.Error())
+	}
+	a.GreaterOrEqual(succeeded, 1)
+
+	// Only the first step is approved, and by a single approver entry.
+	issueGetResp, err := ctl.issueServiceClient.GetIssue(ctx, connect.NewRequest(&v1pb.GetIssueRequest{
+		Name: issueResp.Msg.Name,
+	}))
+	a.NoError(err)
+	issue = issueGetResp.Msg
+	a.Len(issue.Approvers, 1)
+	a.Equal(v1pb.Issue_Approver_APPROVED, issue.Approvers[0].Status)
+	a.Equal(v1pb.Issue_PENDING, issue.ApprovalStatus)
+}